@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"ai-color-palette/config"
+)
+
+// 自检模式的上限：达到任一上限后只保留 return_palette，要求模型立即提交
+const (
+	agentMaxSteps  = 6
+	agentMaxTokens = 16000
+)
+
+const agentInstructions = `
+在提交之前，你可以调用以下工具自检（由服务端在本地计算）：
+- check_contrast：检查颜色两两之间及与黑/白文字的对比度
+- simulate_cvd：模拟红绿色盲、蓝黄色盲等色觉缺陷下的颜色，并给出最难区分的一对
+发现对比度不足或色盲下难以区分时，请修改配色后再次检查。确认无误后调用 return_palette 提交最终结果。
+工具调用轮数有限，请尽量在一轮中同时调用需要的工具。
+`
+
+// generateAgentic 自检模式：向模型同时提供检查工具与 return_palette，在有限轮数内
+// 执行模型请求的检查并把结果作为 tool 消息返回，直到模型提交配色
+func generateAgentic(systemPrompt, userPrompt string, opts Options) (*PaletteResult, error) {
+	cfg := config.AppConfig
+	if cfg.AIAPIKey == "" {
+		return nil, fmt.Errorf("AI API key not configured")
+	}
+
+	reqBody := buildPaletteRequest(cfg, systemPrompt+agentInstructions, userPrompt, opts)
+	profile := ProfileFor(reqBody.Model)
+	if !profile.Tools {
+		return nil, fmt.Errorf("model %s does not support tool calls", reqBody.Model)
+	}
+	paletteTool := buildPaletteToolDefinition()
+	allTools := append([]ToolDefinition{paletteTool}, checkToolDefinitions()...)
+
+	tokens := 0
+	var trace []string
+	for step := 1; step <= agentMaxSteps; step++ {
+		final := step == agentMaxSteps || tokens >= agentMaxTokens
+		reqBody.Tools, reqBody.ToolChoice = allTools, "auto"
+		if final {
+			// 只保留 return_palette，服务商支持时同时强制调用
+			applyOutputMode(&reqBody, paletteTool, profile.Mode)
+			if step > 1 {
+				reqBody.Messages = append(reqBody.Messages, ChatMessage{
+					Role:    "user",
+					Content: TextContent("自检轮数已用完，请立即调用 return_palette 提交最终配色。"),
+				})
+			}
+		}
+
+		messages, usage, err := chatCompletion(cfg, reqBody)
+		if err != nil {
+			return nil, err
+		}
+		tokens += usage.TotalTokens
+		message := messages[0]
+		// 部分服务商不接受回传的推理内容
+		reasoning := message.ReasoningContent
+		message.ReasoningContent = ""
+		log.Printf("[INFO] Agent step %d/%d: %d tool calls, %d tokens used (cap %d)", step, agentMaxSteps, len(message.ToolCalls), tokens, agentMaxTokens)
+		reqBody.Messages = append(reqBody.Messages, message)
+
+		if len(message.ToolCalls) == 0 {
+			if result, err := parsePaletteMessage(&ChatMessage{Content: message.Content, ReasoningContent: reasoning}); err == nil {
+				logAgentSummary(step, tokens, trace)
+				return result, nil
+			}
+			if final {
+				return nil, fmt.Errorf("agent finished %d steps without returning a palette", step)
+			}
+			reqBody.Messages = append(reqBody.Messages, ChatMessage{
+				Role:    "user",
+				Content: TextContent("请继续：需要检查时调用检查工具，确认后调用 return_palette 提交配色。"),
+			})
+			continue
+		}
+
+		if final {
+			// 最后一轮不再执行检查工具，没有有效的 return_palette 即失败
+			for _, call := range message.ToolCalls {
+				if call.Function.Name == paletteToolName {
+					result, err := parseToolCallResult(call)
+					if err != nil {
+						return nil, err
+					}
+					logAgentSummary(step, tokens, append(trace, call.Function.Name))
+					return result, nil
+				}
+			}
+			return nil, fmt.Errorf("agent finished %d steps without returning a palette", step)
+		}
+
+		for _, call := range message.ToolCalls {
+			trace = append(trace, call.Function.Name)
+			var output string
+			switch call.Function.Name {
+			case paletteToolName:
+				result, err := parseToolCallResult(call)
+				if err == nil && opts.Purpose != "" {
+					// 可视化配色未通过校验时把问题作为工具结果返回，让模型在剩余轮数内修正
+					var issues []string
+					if issues, err = checkPurpose(opts.Purpose, result.Colors); err == nil && len(issues) > 0 {
+						err = fmt.Errorf("palette failed %s validation: %s", opts.Purpose, strings.Join(issues, "; "))
+					}
+				}
+				if err == nil {
+					logAgentSummary(step, tokens, trace)
+					return result, nil
+				}
+				output = toolError(err)
+			default:
+				output = runCheckTool(call)
+			}
+			reqBody.Messages = append(reqBody.Messages, ChatMessage{
+				Role:       "tool",
+				Content:    TextContent(output),
+				ToolCallID: call.ID,
+			})
+		}
+	}
+	return nil, fmt.Errorf("agent finished %d steps without returning a palette", agentMaxSteps)
+}
+
+func logAgentSummary(steps, tokens int, trace []string) {
+	log.Printf("[INFO] Agent returned palette after %d steps, %d tokens, tool calls: %s", steps, tokens, strings.Join(trace, " → "))
+}
@@ -0,0 +1,120 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"ai-color-palette/cache"
+	"ai-color-palette/config"
+)
+
+// promptTemplateVersion 用户提示词模板、工具定义或解析逻辑有改动时递增，使旧的缓存失效；
+// 系统提示词的改动已通过其摘要体现在键中
+const promptTemplateVersion = 1
+
+var (
+	cacheOnce     sync.Once
+	responseCache *cache.Cache
+)
+
+// paletteCache 按配置创建配色缓存，AI_CACHE_SIZE 为 0 时返回 nil
+func paletteCache() *cache.Cache {
+	cacheOnce.Do(func() {
+		cfg := config.AppConfig
+		if cfg.AICacheSize > 0 && cfg.AICacheTTL > 0 {
+			responseCache = cache.New(cfg.AICacheSize, cfg.AICacheTTL, cfg.AICacheDir)
+		}
+	})
+	return responseCache
+}
+
+// CacheStats 返回配色缓存的命中情况，未启用时返回 nil
+func CacheStats() *cache.Stats {
+	c := paletteCache()
+	if c == nil {
+		return nil
+	}
+	stats := c.Stats()
+	return &stats
+}
+
+// cacheInput 构成缓存键的请求输入
+type cacheInput struct {
+	Prompt string
+	// Colors 微调与单色替换时的现有配色
+	Colors []string
+	// Target 单色替换的位置
+	Target int
+}
+
+// cachedGeneratePalette 相同请求命中缓存时直接返回；NoCache 时跳过读取但仍写入新结果，
+// 带种子的请求（多候选）本就要求不同结果，不经过缓存
+func cachedGeneratePalette(systemPrompt, userPrompt string, input cacheInput, opts Options) (*PaletteResult, error) {
+	c := paletteCache()
+	if c == nil || opts.Seed != nil {
+		return retryGeneratePalette(systemPrompt, userPrompt, opts)
+	}
+	key := paletteCacheKey(systemPrompt, input, opts)
+	if !opts.NoCache {
+		if data, ok := c.Get(key); ok {
+			var result PaletteResult
+			if err := json.Unmarshal(data, &result); err == nil {
+				log.Printf("[INFO] Serving %s palette from cache", opts.operation)
+				result.Cached = true
+				return &result, nil
+			}
+		}
+	}
+
+	result, err := retryGeneratePalette(systemPrompt, userPrompt, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Purpose != "" {
+		// 未通过用途校验的结果不缓存，下次请求仍有机会重新生成
+		if issues, err := checkPurpose(opts.Purpose, result.Colors); err != nil || len(issues) > 0 {
+			return result, nil
+		}
+	}
+	if data, err := json.Marshal(result); err == nil {
+		c.Set(key, data)
+	}
+	return result, nil
+}
+
+// paletteCacheKey 由模板版本、操作、模型、系统提示词摘要、归一化后的提示词与输入颜色组成，
+// 附带图片时加入图片摘要
+func paletteCacheKey(systemPrompt string, input cacheInput, opts Options) string {
+	parts := []string{
+		fmt.Sprintf("v%d", promptTemplateVersion),
+		opts.operation,
+		paletteModel(config.AppConfig, opts),
+		digest([]byte(systemPrompt)),
+		normalizePrompt(input.Prompt),
+		strings.Join(input.Colors, ","),
+		fmt.Sprintf("target:%d", input.Target),
+	}
+	if opts.Image != nil {
+		parts = append(parts, "image:"+digest(opts.Image.Data))
+	}
+	if opts.Agentic {
+		parts = append(parts, "agentic")
+	}
+	return strings.Join(parts, "|")
+}
+
+// normalizePrompt 忽略大小写、多余空白与句末标点的差异
+func normalizePrompt(prompt string) string {
+	prompt = strings.ToLower(strings.Join(strings.Fields(prompt), " "))
+	return strings.TrimRight(prompt, "。.！!？? ")
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
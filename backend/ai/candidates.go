@@ -0,0 +1,97 @@
+package ai
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"ai-color-palette/config"
+)
+
+// MaxCandidates 单次请求最多生成的候选配色数
+const MaxCandidates = 6
+
+// GenerateCandidates 生成 count 个候选配色。模型档案支持 n 参数时一次请求取回全部候选，
+// 否则并行发起 count 次生成，逐个提高温度并使用不同种子以拉开差异；部分失败时只返回成功的候选
+func GenerateCandidates(prompt string, count int, opts Options) ([]*PaletteResult, error) {
+	if count < 1 || count > MaxCandidates {
+		return nil, fmt.Errorf("candidates must be between 1 and %d", MaxCandidates)
+	}
+	cfg := config.AppConfig
+	if count > 1 && ProfileFor(paletteModel(cfg, opts)).N {
+		results, err := generateWithN(cfg, prompt, count, opts)
+		if err == nil {
+			return results, nil
+		}
+		log.Printf("[WARN] Generate %d candidates with n failed: %v, falling back to parallel requests", count, err)
+	}
+
+	results := make([]*PaletteResult, count)
+	errs := make([]error, count)
+	base := time.Now().UnixNano()
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			variant := opts
+			variant.Temperature = candidateTemperature(i)
+			seed := base + int64(i)
+			variant.Seed = &seed
+			results[i], errs[i] = GenerateColorPalette(prompt, variant)
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded []*PaletteResult
+	var lastErr error
+	for i, result := range results {
+		if errs[i] != nil {
+			log.Printf("[WARN] Candidate %d failed: %v", i+1, errs[i])
+			lastErr = errs[i]
+			continue
+		}
+		succeeded = append(succeeded, result)
+	}
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("all %d candidates failed, last error: %w", count, lastErr)
+	}
+	return succeeded, nil
+}
+
+// candidateTemperature 第 i 个候选的采样温度，从默认值起逐步升高
+func candidateTemperature(i int) float64 {
+	return math.Min(1.2, 0.7+0.1*float64(i))
+}
+
+// generateWithN 用一次带 n 参数的请求取回多个候选，无法解析的候选直接丢弃
+func generateWithN(cfg *config.Config, prompt string, count int, opts Options) ([]*PaletteResult, error) {
+	if cfg.AIAPIKey == "" {
+		return nil, fmt.Errorf("AI API key not configured")
+	}
+	opts.operation = "generate"
+	systemPrompt, userPrompt := generatePrompts(prompt, opts)
+	reqBody := buildPaletteRequest(cfg, systemPrompt, userPrompt, opts)
+	reqBody.N = count
+	reqBody.Temperature = 0.9
+
+	messages, err := postChatChoices(cfg, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	var results []*PaletteResult
+	for i := range messages {
+		result, err := parsePaletteMessage(&messages[i])
+		if err != nil {
+			log.Printf("[WARN] Candidate %d unparsable: %v", i+1, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("none of %d choices returned a palette", len(messages))
+	}
+	return results, nil
+}
@@ -0,0 +1,385 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"ai-color-palette/config"
+)
+
+// 结构化输出方式，按可靠程度从高到低排列
+const (
+	// OutputForcedTool tools 并通过 tool_choice 指定函数
+	OutputForcedTool = "forced_tool"
+	// OutputTool tools 且 tool_choice 为 auto，由模型决定是否调用
+	OutputTool = "tool"
+	// OutputJSONSchema response_format 为 json_schema
+	OutputJSONSchema = "json_schema"
+	// OutputJSONObject response_format 为 json_object，结构只在提示词中说明
+	OutputJSONObject = "json_object"
+	// OutputText 不声明格式，在提示词中要求输出 JSON 并从正文中提取
+	OutputText = "text"
+)
+
+// 能力档案的来源
+const (
+	sourceDefault    = "default"
+	sourceConfigured = "configured"
+	sourceProbed     = "probed"
+)
+
+// Profile 模型支持的请求能力，决定结构化输出方式以及多候选能否用 n 参数一次取回
+type Profile struct {
+	Model      string `json:"model"`
+	Tools      bool   `json:"tools"`
+	ForcedTool bool   `json:"forced_tool"`
+	JSONSchema bool   `json:"json_schema"`
+	JSONObject bool   `json:"json_object"`
+	N          bool   `json:"n"`
+	// Source 档案来自配置、探测还是默认值
+	Source string `json:"source"`
+	// Downgraded 运行中被服务商拒绝而停用的输出方式
+	Downgraded []string `json:"downgraded,omitempty"`
+	// Mode 当前采用的结构化输出方式
+	Mode string `json:"mode"`
+}
+
+// OutputMode 返回档案支持的最强结构化输出方式
+func (p Profile) OutputMode() string {
+	switch {
+	case p.Tools && p.ForcedTool:
+		return OutputForcedTool
+	case p.Tools:
+		return OutputTool
+	case p.JSONSchema:
+		return OutputJSONSchema
+	case p.JSONObject:
+		return OutputJSONObject
+	default:
+		return OutputText
+	}
+}
+
+// profileEntry 一个模型的档案，resolve 只执行一次，并发的首次请求等待同一次探测
+type profileEntry struct {
+	once    sync.Once
+	profile *Profile
+}
+
+var (
+	profilesMu sync.Mutex
+	profiles   = map[string]*profileEntry{}
+)
+
+// ProfileFor 返回模型的能力档案：优先使用 AI_CAPABILITIES 中的配置，其次在开启
+// AI_PROBE_CAPABILITIES 时发送探测请求，否则假定支持全部工具调用能力，被拒绝后再逐级降级
+func ProfileFor(model string) Profile {
+	profilesMu.Lock()
+	e, ok := profiles[model]
+	if !ok {
+		e = &profileEntry{}
+		profiles[model] = e
+	}
+	profilesMu.Unlock()
+
+	// 探测期间不持有 profilesMu，其他模型的请求与降级不受影响
+	e.once.Do(func() {
+		p := resolveProfile(config.AppConfig, model)
+		profilesMu.Lock()
+		e.profile = p
+		profilesMu.Unlock()
+	})
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	return *e.profile
+}
+
+// ResolvedProfile 返回已解析过的模型档案，不会触发探测
+func ResolvedProfile(model string) (Profile, bool) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	if e, ok := profiles[model]; ok && e.profile != nil {
+		return *e.profile, true
+	}
+	return Profile{}, false
+}
+
+func resolveProfile(cfg *config.Config, model string) *Profile {
+	p, err := configuredProfile(cfg.AICapabilities, model)
+	if err != nil {
+		log.Printf("[WARN] Ignore AI_CAPABILITIES: %v", err)
+	}
+	if p == nil && cfg.AIProbeCapabilities && cfg.AIAPIKey != "" {
+		p = probeProfile(cfg, model)
+	}
+	if p == nil {
+		p = &Profile{Tools: true, ForcedTool: true, Source: sourceDefault}
+	}
+	p.Model = model
+	// 兼容旧配置
+	p.N = p.N || cfg.AISupportsN
+	p.Mode = p.OutputMode()
+	log.Printf("[INFO] Capability profile for %s (%s): output mode %s, n=%t", model, p.Source, p.Mode, p.N)
+	return p
+}
+
+// Profiles 返回已解析过的全部模型档案
+func Profiles() []Profile {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	list := make([]Profile, 0, len(profiles))
+	for _, e := range profiles {
+		if e.profile != nil {
+			list = append(list, *e.profile)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Model < list[j].Model })
+	return list
+}
+
+// configuredProfile 解析 AI_CAPABILITIES，格式为“模型=能力,能力;模型=能力”，模型写 * 时作用于
+// 未单独配置的所有模型；能力可选 tools、forced_tool、json_schema、json_object、n，留空表示只支持纯文本
+func configuredProfile(spec, model string) (*Profile, error) {
+	var fallback *Profile
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, caps, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q has no '='", entry)
+		}
+		name = strings.TrimSpace(name)
+		if name != model && name != "*" {
+			continue
+		}
+		p := &Profile{Source: sourceConfigured}
+		for _, c := range strings.Split(caps, ",") {
+			switch strings.TrimSpace(c) {
+			case "":
+			case "tools":
+				p.Tools = true
+			case OutputForcedTool:
+				p.Tools, p.ForcedTool = true, true
+			case OutputJSONSchema:
+				p.JSONSchema = true
+			case OutputJSONObject:
+				p.JSONObject = true
+			case "n":
+				p.N = true
+			default:
+				return nil, fmt.Errorf("unknown capability %q for %s", strings.TrimSpace(c), name)
+			}
+		}
+		if name == model {
+			return p, nil
+		}
+		fallback = p
+	}
+	return fallback, nil
+}
+
+// downgradeProfile 服务商拒绝某种输出方式后停用它，之后的请求改用次一级的方式
+func downgradeProfile(model, mode string, cause error) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	e, ok := profiles[model]
+	if !ok || e.profile == nil {
+		return
+	}
+	p := e.profile
+	switch mode {
+	case OutputForcedTool:
+		p.ForcedTool = false
+	case OutputTool:
+		p.Tools, p.ForcedTool = false, false
+	case OutputJSONSchema:
+		p.JSONSchema = false
+	case OutputJSONObject:
+		p.JSONObject = false
+	default:
+		return
+	}
+	p.Downgraded = append(p.Downgraded, mode)
+	p.Mode = p.OutputMode()
+	log.Printf("[WARN] %s rejected %s output (%v), switching to %s", model, mode, cause, p.Mode)
+}
+
+// apiError 服务商返回的非 200 响应
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// rejectsOutputMode 判断错误是否为服务商不支持请求中的 tools / tool_choice / response_format
+func rejectsOutputMode(err error) bool {
+	var ae *apiError
+	if !errors.As(err, &ae) || (ae.StatusCode != http.StatusBadRequest && ae.StatusCode != http.StatusUnprocessableEntity) {
+		return false
+	}
+	body := strings.ToLower(ae.Body)
+	for _, keyword := range []string{"tool", "function", "response_format", "json_schema", "json_object", "schema"} {
+		if strings.Contains(body, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResponseFormat 请求的 response_format 字段
+type ResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *JSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+type JSONSchemaFormat struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// applyOutputMode 按输出方式设置请求：工具方式附带 tool，其余方式把 tool 的参数结构作为
+// 期望的 JSON 输出写入系统提示词，json_schema 同时通过 response_format 约束
+func applyOutputMode(req *ChatRequest, tool ToolDefinition, mode string) {
+	req.outputMode = mode
+	req.Tools, req.ToolChoice, req.ResponseFormat = nil, nil, nil
+	switch mode {
+	case OutputForcedTool:
+		req.Tools = []ToolDefinition{tool}
+		req.ToolChoice = map[string]interface{}{
+			"type":     "function",
+			"function": map[string]string{"name": tool.Function.Name},
+		}
+		return
+	case OutputTool:
+		req.Tools = []ToolDefinition{tool}
+		req.ToolChoice = "auto"
+		return
+	case OutputJSONSchema:
+		req.ResponseFormat = &ResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &JSONSchemaFormat{Name: tool.Function.Name, Schema: tool.Function.Parameters},
+		}
+	case OutputJSONObject:
+		req.ResponseFormat = &ResponseFormat{Type: "json_object"}
+	}
+
+	schema, _ := json.Marshal(tool.Function.Parameters)
+	instruction := fmt.Sprintf("\n本次请求不使用函数调用。上文要求调用 %s 的地方，改为直接输出一个 JSON 对象作为它的参数，不要输出任何其他文字或代码块。JSON Schema：\n%s\n",
+		tool.Function.Name, schema)
+	for i := range req.Messages {
+		if req.Messages[i].Role == "system" {
+			req.Messages[i].Content = TextContent(req.Messages[i].Content.String() + instruction)
+			return
+		}
+	}
+}
+
+// postStructured 按模型当前的输出方式发送请求，服务商拒绝该方式时降级后立即重发
+func postStructured(cfg *config.Config, build func() ChatRequest) (*ChatMessage, error) {
+	for {
+		reqBody := build()
+		message, err := postChat(cfg, reqBody)
+		if err != nil && reqBody.outputMode != OutputText && rejectsOutputMode(err) {
+			downgradeProfile(reqBody.Model, reqBody.outputMode, err)
+			continue
+		}
+		return message, err
+	}
+}
+
+// structuredArguments 取出模型按 tool 参数结构返回的 JSON 文本：优先取对应的工具调用，
+// 其次取正文
+func structuredArguments(message *ChatMessage, toolName string) (string, bool) {
+	for _, call := range message.ToolCalls {
+		if call.Function.Name == toolName {
+			return call.Function.Arguments, true
+		}
+	}
+	if content := message.Content.String(); strings.TrimSpace(content) != "" {
+		return content, true
+	}
+	return "", false
+}
+
+// probeProfile 用极小的请求依次探测指定工具、自动工具调用、json_schema、json_object 与 n 参数，
+// 每项只看服务商是否接受并返回了相应格式的结果
+func probeProfile(cfg *config.Config, model string) *Profile {
+	probeTool := ToolDefinition{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        "probe",
+			Description: "Report that the probe succeeded.",
+			Parameters: map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"ok": map[string]interface{}{"type": "boolean"}},
+				"required":             []string{"ok"},
+				"additionalProperties": false,
+			},
+		},
+	}
+	try := func(mode string, n int) []ChatMessage {
+		req := ChatRequest{
+			Model: model,
+			Messages: []ChatMessage{
+				{Role: "system", Content: TextContent("Call probe with ok set to true.")},
+				{Role: "user", Content: TextContent(`Reply with the JSON object {"ok": true}.`)},
+			},
+			MaxTokens: 64,
+			N:         n,
+			operation: "capability-probe",
+		}
+		if mode != "" {
+			applyOutputMode(&req, probeTool, mode)
+		}
+		messages, _, err := chatCompletion(cfg, req)
+		if err != nil {
+			log.Printf("[INFO] Capability probe %s for %s failed: %v", probeName(mode, n), model, err)
+			return nil
+		}
+		return messages
+	}
+	returnsJSON := func(messages []ChatMessage) bool {
+		if len(messages) == 0 {
+			return false
+		}
+		var repairs repairLog
+		return json.Valid([]byte(cleanModelText(messages[0].Content.String(), &repairs)))
+	}
+	callsProbe := func(messages []ChatMessage) bool {
+		return len(messages) > 0 && len(messages[0].ToolCalls) > 0 && messages[0].ToolCalls[0].Function.Name == "probe"
+	}
+
+	p := &Profile{Source: sourceProbed}
+	if callsProbe(try(OutputForcedTool, 0)) {
+		p.Tools, p.ForcedTool = true, true
+	} else {
+		p.Tools = callsProbe(try(OutputTool, 0))
+	}
+	if !p.Tools {
+		p.JSONSchema = returnsJSON(try(OutputJSONSchema, 0))
+		if !p.JSONSchema {
+			p.JSONObject = returnsJSON(try(OutputJSONObject, 0))
+		}
+	}
+	p.N = len(try("", 2)) == 2
+	return p
+}
+
+func probeName(mode string, n int) string {
+	if mode == "" {
+		return fmt.Sprintf("n=%d", n)
+	}
+	return mode
+}
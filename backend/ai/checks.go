@@ -0,0 +1,162 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"ai-color-palette/colorutil"
+)
+
+// 自检模式下模型可调用、由服务端本地执行的检查工具
+const (
+	checkContrastToolName = "check_contrast"
+	simulateCVDToolName   = "simulate_cvd"
+)
+
+// maxCheckColors 检查工具单次接受的颜色数上限
+const maxCheckColors = 12
+
+func checkToolDefinitions() []ToolDefinition {
+	colors := map[string]interface{}{
+		"type":        "array",
+		"description": "待检查的 HEX 颜色，格式为 #RRGGBB。",
+		"items":       map[string]interface{}{"type": "string", "pattern": "^#[0-9A-Fa-f]{6}$"},
+		"minItems":    2,
+		"maxItems":    maxCheckColors,
+	}
+	parameters := map[string]interface{}{
+		"type":                 "object",
+		"properties":           map[string]interface{}{"colors": colors},
+		"required":             []string{"colors"},
+		"additionalProperties": false,
+	}
+	return []ToolDefinition{
+		{
+			Type: "function",
+			Function: ToolFunction{
+				Name:        checkContrastToolName,
+				Description: "计算颜色两两之间的 WCAG 对比度及等级，以及每个颜色上黑/白文字的最佳对比度。",
+				Parameters:  parameters,
+			},
+		},
+		{
+			Type: "function",
+			Function: ToolFunction{
+				Name:        simulateCVDToolName,
+				Description: "模拟各类色觉缺陷下看到的颜色，给出两两最小 ΔE2000 及最难区分的一对，ΔE 低于 10 时较难区分。",
+				Parameters:  parameters,
+			},
+		},
+	}
+}
+
+// runCheckTool 执行检查工具并返回 JSON 文本结果，参数错误也以结果形式返回给模型
+func runCheckTool(call ToolCall) string {
+	var args struct {
+		Colors []string `json:"colors"`
+	}
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+		return toolError(fmt.Errorf("parse arguments: %w", err))
+	}
+	if len(args.Colors) < 2 || len(args.Colors) > maxCheckColors {
+		return toolError(fmt.Errorf("colors must contain 2 to %d colors", maxCheckColors))
+	}
+	rgbs := make([]colorutil.RGB, len(args.Colors))
+	for i, color := range args.Colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return toolError(err)
+		}
+		rgbs[i] = c
+	}
+
+	var output interface{}
+	switch call.Function.Name {
+	case checkContrastToolName:
+		output = checkContrast(rgbs)
+	case simulateCVDToolName:
+		output = simulateCVD(rgbs)
+	default:
+		return toolError(fmt.Errorf("unknown tool: %s", call.Function.Name))
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return toolError(err)
+	}
+	return string(data)
+}
+
+// toolError 以 JSON 返回错误，输出格式问题附带修正说明
+func toolError(err error) string {
+	output := map[string]string{"error": err.Error()}
+	if correction := correctionFor(err); correction != "" {
+		output["fix"] = correction
+	}
+	data, _ := json.Marshal(output)
+	return string(data)
+}
+
+type contrastPair struct {
+	A     string  `json:"a"`
+	B     string  `json:"b"`
+	Ratio float64 `json:"ratio"`
+	Level string  `json:"level"`
+}
+
+type textContrast struct {
+	Color    string  `json:"color"`
+	BestText string  `json:"best_text"`
+	Ratio    float64 `json:"ratio"`
+	Level    string  `json:"level"`
+}
+
+func checkContrast(rgbs []colorutil.RGB) map[string]interface{} {
+	var pairs []contrastPair
+	for i := range rgbs {
+		for j := i + 1; j < len(rgbs); j++ {
+			ratio := colorutil.ContrastRatio(rgbs[i], rgbs[j])
+			pairs = append(pairs, contrastPair{rgbs[i].Hex(), rgbs[j].Hex(), colorutil.Round(ratio, 2), colorutil.ContrastLevel(ratio)})
+		}
+	}
+	text := make([]textContrast, len(rgbs))
+	for i, c := range rgbs {
+		best := colorutil.BestTextColor(c)
+		ratio := colorutil.ContrastRatio(c, best)
+		text[i] = textContrast{c.Hex(), best.Hex(), colorutil.Round(ratio, 2), colorutil.ContrastLevel(ratio)}
+	}
+	return map[string]interface{}{"pairs": pairs, "text": text}
+}
+
+type cvdResult struct {
+	Colors    []string  `json:"colors"`
+	MinDeltaE float64   `json:"min_delta_e"`
+	Closest   [2]string `json:"closest"`
+}
+
+func simulateCVD(rgbs []colorutil.RGB) map[string]cvdResult {
+	results := map[string]cvdResult{"normal": closestPair(rgbs, rgbs)}
+	for _, kind := range colorutil.CVDTypes {
+		simulated := make([]colorutil.RGB, len(rgbs))
+		for i, c := range rgbs {
+			simulated[i] = colorutil.SimulateCVD(c, kind)
+		}
+		results[kind] = closestPair(rgbs, simulated)
+	}
+	return results
+}
+
+// closestPair 在模拟后的颜色中找出 ΔE 最小的一对，Closest 报告原始颜色以便模型定位
+func closestPair(original, simulated []colorutil.RGB) cvdResult {
+	r := cvdResult{Colors: make([]string, len(simulated)), MinDeltaE: math.Inf(1)}
+	for i, c := range simulated {
+		r.Colors[i] = c.Hex()
+		for j := i + 1; j < len(simulated); j++ {
+			if d := colorutil.DeltaE(c, simulated[j]); d < r.MinDeltaE {
+				r.MinDeltaE, r.Closest = d, [2]string{original[i].Hex(), original[j].Hex()}
+			}
+		}
+	}
+	r.MinDeltaE = colorutil.Round(r.MinDeltaE, 2)
+	return r
+}
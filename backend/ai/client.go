@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"ai-color-palette/billing"
 	"ai-color-palette/config"
 )
 
@@ -23,9 +25,13 @@ var (
 )
 
 type ChatMessage struct {
-	Role      string     `json:"role"`
-	Content   string     `json:"content"`
-	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	Role      string         `json:"role"`
+	Content   MessageContent `json:"content"`
+	ToolCalls []ToolCall     `json:"tool_calls,omitempty"`
+	// ToolCallID role 为 tool 时对应的工具调用ID
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	// ReasoningContent 思考模型单独返回的推理内容，部分模型会把结果留在这里
+	ReasoningContent string `json:"reasoning_content,omitempty"`
 }
 
 type ChatRequest struct {
@@ -35,12 +41,30 @@ type ChatRequest struct {
 	MaxTokens   int              `json:"max_tokens,omitempty"`
 	Tools       []ToolDefinition `json:"tools,omitempty"`
 	ToolChoice  interface{}      `json:"tool_choice,omitempty"`
+	// N 一次请求返回的候选数，仅在服务商支持时设置
+	N int `json:"n,omitempty"`
+	// Seed 采样种子，为空时不发送
+	Seed *int64 `json:"seed,omitempty"`
+	// ResponseFormat JSON 输出约束，仅在模型不使用工具调用时设置
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	// outputMode 请求采用的结构化输出方式，不发送
+	outputMode string
+	// operation 用量统计中归属的操作，不发送
+	operation string
 }
 
 type ChatResponse struct {
 	Choices []struct {
 		Message ChatMessage `json:"message"`
 	} `json:"choices"`
+	Usage Usage `json:"usage"`
+}
+
+// Usage 服务商返回的 token 用量，未返回时为零值
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
 type ToolCall struct {
@@ -68,17 +92,55 @@ type ToolFunction struct {
 type PaletteResult struct {
 	Colors []string `json:"colors"`
 	Advice string   `json:"advice"`
+	// Details 逐色的名称、角色、用量与理由，模型未返回时为空
+	Details []ColorDetail `json:"details,omitempty"`
+	// Repairs 解析模型输出时应用的修复项，输出规范时为空
+	Repairs []string `json:"repairs,omitempty"`
+	// Cached 结果来自缓存
+	Cached bool `json:"-"`
+}
+
+// Options 生成请求的可选参数
+type Options struct {
+	// Image 附带的参考图片，发送给视觉模型
+	Image *Image
+	// Purpose 数据可视化用途（sequential / diverging / categorical），为空时按品牌配色生成
+	Purpose string
+	// Temperature 采样温度，为 0 时使用默认值
+	Temperature float64
+	// Seed 采样种子，为空时不发送
+	Seed *int64
+	// Agentic 自检模式：模型可先调用对比度与色盲模拟工具检查，再提交配色
+	Agentic bool
+	// NoCache 跳过缓存读取，强制重新生成（新结果仍会写入缓存）
+	NoCache bool
+	// operation 用量统计中归属的操作，由各入口函数设置
+	operation string
+}
+
+// GenerateColorPalette 使用AI生成配色方案，支持3次重试，相同请求优先使用缓存
+func GenerateColorPalette(prompt string, opts Options) (*PaletteResult, error) {
+	opts.operation = "generate"
+	systemPrompt, userPrompt := generatePrompts(prompt, opts)
+	return cachedGeneratePalette(systemPrompt, userPrompt, cacheInput{Prompt: prompt}, opts)
 }
 
-// GenerateColorPalette 使用AI生成配色方案，支持3次重试
-func GenerateColorPalette(prompt string) (*PaletteResult, error) {
+// generatePrompts 构造全新生成配色时的系统提示词与用户提示词
+func generatePrompts(prompt string, opts Options) (string, string) {
 	systemPrompt := buildBaseSystemPrompt()
+	if opts.Purpose != "" {
+		systemPrompt = buildPurposeSystemPrompt(opts.Purpose)
+	}
 	userPrompt := fmt.Sprintf("请你帮我生成这样的配色：%s", prompt)
-	return retryGeneratePalette(systemPrompt, userPrompt)
+	if opts.Image != nil {
+		userPrompt += "\n请参考附带图片的氛围与主要色彩。"
+	}
+	return systemPrompt, userPrompt
 }
 
 // GeneratePaletteWithSingleColor 仅替换指定颜色，保持其他颜色不变
-func GeneratePaletteWithSingleColor(baseColors []string, targetIndex int, prompt string) (*PaletteResult, error) {
+func GeneratePaletteWithSingleColor(baseColors []string, targetIndex int, prompt string, opts Options) (*PaletteResult, error) {
+	opts.operation = "regenerate-color"
 	normalized, ok := normalizeColors(baseColors)
 	if !ok {
 		return nil, fmt.Errorf("base colors must be 5 valid hex values")
@@ -96,7 +158,7 @@ func GeneratePaletteWithSingleColor(baseColors []string, targetIndex int, prompt
 		prompt,
 	)
 
-	result, err := retryGeneratePalette(systemPrompt, userPrompt)
+	result, err := cachedGeneratePalette(systemPrompt, userPrompt, cacheInput{Prompt: prompt, Colors: normalized, Target: targetIndex}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +181,8 @@ func GeneratePaletteWithSingleColor(baseColors []string, targetIndex int, prompt
 }
 
 // RefinePalette 基于现有配色方案进行微调
-func RefinePalette(currentColors []string, prompt string) (*PaletteResult, error) {
+func RefinePalette(currentColors []string, prompt string, opts Options) (*PaletteResult, error) {
+	opts.operation = "refine"
 	normalized, ok := normalizeColors(currentColors)
 	if !ok {
 		return nil, fmt.Errorf("current colors must be 5 valid hex values")
@@ -131,66 +194,180 @@ func RefinePalette(currentColors []string, prompt string) (*PaletteResult, error
 		strings.Join(normalized, ", "),
 		prompt,
 	)
+	if opts.Image != nil {
+		userPrompt += "请同时参考附带图片。"
+	}
 
-	return retryGeneratePalette(systemPrompt, userPrompt)
+	return cachedGeneratePalette(systemPrompt, userPrompt, cacheInput{Prompt: prompt, Colors: normalized}, opts)
 }
 
-func retryGeneratePalette(systemPrompt, userPrompt string) (*PaletteResult, error) {
+func retryGeneratePalette(systemPrompt, userPrompt string, opts Options) (*PaletteResult, error) {
+	if opts.Agentic {
+		// 自检循环自身有轮数与 token 上限，失败后退回一次性生成
+		result, err := generateAgentic(systemPrompt, userPrompt, opts)
+		if err == nil {
+			return result, nil
+		}
+		log.Printf("[WARN] Agentic generation failed: %v, falling back to direct generation", err)
+	}
 	const maxRetries = 3
 	var lastErr error
+	var unchecked *PaletteResult
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		log.Printf("[INFO] Attempting to generate palette (attempt %d/%d)", attempt, maxRetries)
 
-		result, err := attemptGenerateWithPrompt(systemPrompt, userPrompt)
+		result, err := attemptGenerateWithPrompt(systemPrompt, userPrompt, opts)
+		if err == nil && opts.Purpose != "" {
+			// 可视化配色需满足用途约束，未通过时把问题反馈给模型重试
+			var issues []string
+			if issues, err = checkPurpose(opts.Purpose, result.Colors); err == nil && len(issues) > 0 {
+				unchecked = result
+				userPrompt += "\n上一次结果未通过校验：" + strings.Join(issues, "；") + "。请修正后重新返回。"
+				err = fmt.Errorf("palette failed %s validation: %s", opts.Purpose, strings.Join(issues, "; "))
+			}
+		}
 		if err == nil {
 			return result, nil
 		}
 
 		lastErr = err
 		log.Printf("[WARN] Attempt %d failed: %v", attempt, err)
+		if errors.Is(err, billing.ErrBudgetExceeded) {
+			break
+		}
+		if correction := correctionFor(err); correction != "" {
+			// 输出无法修复时告诉模型具体问题，而不是原样重试
+			userPrompt += "\n上一次输出有误：" + correction + "。请修正后通过 return_palette 重新返回。"
+		}
 
 		if attempt < maxRetries {
 			time.Sleep(time.Second * time.Duration(attempt))
 		}
 	}
 
-	log.Printf("[ERROR] Failed to generate palette after %d attempts", maxRetries)
-	return nil, fmt.Errorf("all %d retry attempts failed, last error: %w", maxRetries, lastErr)
+	if unchecked != nil {
+		log.Printf("[WARN] Returning last %s palette that did not pass validation", opts.Purpose)
+		return unchecked, nil
+	}
+	log.Printf("[ERROR] Failed to generate palette: %v", lastErr)
+	return nil, fmt.Errorf("generate palette failed, last error: %w", lastErr)
 }
 
-func attemptGenerateWithPrompt(systemPrompt, userPrompt string) (*PaletteResult, error) {
+func attemptGenerateWithPrompt(systemPrompt, userPrompt string, opts Options) (*PaletteResult, error) {
 	cfg := config.AppConfig
 	if cfg.AIAPIKey == "" {
 		return nil, fmt.Errorf("AI API key not configured")
 	}
 
-	paletteTool := buildPaletteToolDefinition()
-	toolChoice := "auto"
+	message, err := postStructured(cfg, func() ChatRequest {
+		return buildPaletteRequest(cfg, systemPrompt, userPrompt, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] AI returns messages: %+v", message)
+	return parsePaletteMessage(message)
+}
+
+// buildPaletteRequest 构造要求模型按 return_palette 参数结构返回配色的请求，输出方式取决于模型的能力档案
+func buildPaletteRequest(cfg *config.Config, systemPrompt, userPrompt string, opts Options) ChatRequest {
+	model := paletteModel(cfg, opts)
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.7
+	}
 
-	reqBody := ChatRequest{
-		Model: cfg.AIModel,
+	req := ChatRequest{
+		Model: model,
 		Messages: []ChatMessage{
-			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: userPrompt},
+			{Role: "system", Content: TextContent(systemPrompt)},
+			{Role: "user", Content: userContent(userPrompt, opts.Image)},
 		},
-		Temperature: 0.7,
-		MaxTokens:   200,
-		Tools:       []ToolDefinition{paletteTool},
-		ToolChoice:  toolChoice,
+		Temperature: temperature,
+		MaxTokens:   800,
+		Seed:        opts.Seed,
+		operation:   opts.operation,
+	}
+	applyOutputMode(&req, buildPaletteToolDefinition(), ProfileFor(model).Mode)
+	return req
+}
+
+// paletteModel 请求附带图片且配置了视觉模型时使用视觉模型
+func paletteModel(cfg *config.Config, opts Options) string {
+	if opts.Image != nil && cfg.AIVisionModel != "" {
+		return cfg.AIVisionModel
+	}
+	return cfg.AIModel
+}
+
+// parsePaletteMessage 从助手消息中解析配色，依次尝试工具调用、正文与思考模型的 reasoning_content，
+// 可恢复的格式问题会被修复并记录
+func parsePaletteMessage(message *ChatMessage) (*PaletteResult, error) {
+	if len(message.ToolCalls) > 0 {
+		for _, call := range message.ToolCalls {
+			if call.Function.Name != paletteToolName {
+				continue
+			}
+			result, err := parseToolCallResult(call)
+			if err != nil {
+				return nil, err
+			}
+			log.Println("[INFO] AI Tool Call Generated Successfully")
+			return result, nil
+		}
+		return nil, newOutputError("请调用 return_palette 返回配色", "tool call returned without expected palette data")
+	}
+
+	if content := message.Content.String(); content != "" {
+		if result, ok := parseResultFromContent(content); ok {
+			log.Println("[INFO] AI returned result in content, using parsed result")
+			return result, nil
+		}
 	}
+	if message.ReasoningContent != "" {
+		if result, ok := parseResultFromContent(message.ReasoningContent); ok {
+			result.Repairs = append([]string{repairReasoningContent}, result.Repairs...)
+			logRepairs(result.Repairs)
+			return result, nil
+		}
+	}
+
+	return nil, newOutputError("没有调用 return_palette，也没有可解析的结果，请调用 return_palette 返回配色",
+		"AI Tool Call Failed: no tool_calls and no parsable result in content")
+}
+
+// postChat 发送一次 chat/completions 请求并返回助手消息
+func postChat(cfg *config.Config, reqBody ChatRequest) (*ChatMessage, error) {
+	messages, err := postChatChoices(cfg, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	return &messages[0], nil
+}
+
+// postChatChoices 发送一次 chat/completions 请求并返回全部候选的助手消息，请求设置 n 时可能有多个
+func postChatChoices(cfg *config.Config, reqBody ChatRequest) ([]ChatMessage, error) {
+	messages, _, err := chatCompletion(cfg, reqBody)
+	return messages, err
+}
 
+// chatCompletion 发送一次 chat/completions 请求，返回全部候选的助手消息与 token 用量
+func chatCompletion(cfg *config.Config, reqBody ChatRequest) ([]ChatMessage, Usage, error) {
+	if err := billing.CheckBudget(); err != nil {
+		return nil, Usage{}, err
+	}
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+		return nil, Usage{}, fmt.Errorf("marshal request: %w", err)
 	}
-	log.Printf("[INFO] AI input messages: %s", jsonData)
+	logChatRequest(reqBody, jsonData)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.AITimeout)*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", cfg.AIAPIBaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, Usage{}, fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -199,54 +376,45 @@ func attemptGenerateWithPrompt(systemPrompt, userPrompt string) (*PaletteResult,
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
+		return nil, Usage{}, fmt.Errorf("send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, Usage{}, &apiError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var chatResp ChatResponse
 	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+		return nil, Usage{}, fmt.Errorf("decode response: %w", err)
 	}
+	billing.Record(reqBody.operation, reqBody.Model, cfg.AIAPIKey, chatResp.Usage.PromptTokens, chatResp.Usage.CompletionTokens)
 
 	if len(chatResp.Choices) == 0 {
-		return nil, fmt.Errorf("no response from AI")
+		return nil, Usage{}, fmt.Errorf("no response from AI")
 	}
 
-	choice := chatResp.Choices[0]
-	message := choice.Message
-	if message.Role != "assistant" {
-		return nil, fmt.Errorf("unexpected message role: %s", message.Role)
-	}
-	log.Printf("[INFO] AI returns messages: %s", message)
-	if len(message.ToolCalls) > 0 {
-		for _, call := range message.ToolCalls {
-			if call.Function.Name != paletteToolName {
-				continue
-			}
-			result, err := parseToolCallResult(call)
-			if err != nil {
-				return nil, err
-			}
-			log.Println("[INFO] AI Tool Call Generated Successfully")
-			return result, nil
+	messages := make([]ChatMessage, len(chatResp.Choices))
+	for i, choice := range chatResp.Choices {
+		if choice.Message.Role != "assistant" {
+			return nil, Usage{}, fmt.Errorf("unexpected message role: %s", choice.Message.Role)
 		}
-		return nil, fmt.Errorf("tool call returned without expected palette data")
+		messages[i] = choice.Message
 	}
+	return messages, chatResp.Usage, nil
+}
 
-	if message.Content != "" {
-		result, ok := parseResultFromContent(message.Content)
-		if ok {
-			log.Println("[INFO] AI returned result in content, using parsed result")
-			return result, nil
+// logChatRequest 记录请求内容，带图片时只记录摘要以免日志过大
+func logChatRequest(reqBody ChatRequest, jsonData []byte) {
+	for _, msg := range reqBody.Messages {
+		if !msg.Content.hasImage() {
+			continue
 		}
+		log.Printf("[INFO] AI input messages: (with image) %s", msg.Content.String())
+		return
 	}
-
-	return nil, fmt.Errorf("AI Tool Call Failed: no tool_calls and no parsable result in content")
+	log.Printf("[INFO] AI input messages: %s", jsonData)
 }
 
 func buildBaseSystemPrompt() string {
@@ -258,6 +426,7 @@ func buildBaseSystemPrompt() string {
 3. 建立【色彩秩序】：通过明度阶梯（从20%到80%亮度）建立视觉节奏
 4. 添加【中性调和剂】：适当加入平衡色
 5. 最终效果需呈现【动态和谐】- 既有视觉冲击力，又保持整体统一性
+同时在 details 中为每个颜色给出名称、角色（primary/secondary/accent/neutral）、建议面积占比（合计 100）与一句话理由。
 `
 }
 
@@ -266,7 +435,7 @@ func buildSingleColorSystemPrompt() string {
 你是一个专业的配色设计师。给定一组现有配色，你只允许替换指定的一个颜色，其余颜色必须保持不变。
 你必须通过调用 return_palette 工具函数返回结果，不要输出任何自然语言文本。
 输出的颜色顺序必须与输入保持一致，只替换被指定的颜色位置。
-请同时给出新的配色使用建议。
+请同时给出新的配色使用建议，并在 details 中为每个颜色给出名称、角色、建议面积占比与一句话理由。
 `
 }
 
@@ -289,23 +458,20 @@ func extractColors(text string) []string {
 	return colors
 }
 
+// parseResultFromContent 从正文中解析配色：先按 return_palette 参数修复解析，
+// 失败时退而提取正文中出现的前 5 个 HEX 颜色
 func parseResultFromContent(content string) (*PaletteResult, bool) {
-	var payload struct {
-		Colors []string `json:"colors"`
-		Advice string   `json:"advice"`
-	}
-	if err := json.Unmarshal([]byte(content), &payload); err == nil {
-		if len(payload.Colors) == 5 {
-			normalized, ok := normalizeColors(payload.Colors)
-			if ok {
-				return &PaletteResult{Colors: normalized, Advice: strings.TrimSpace(payload.Advice)}, true
-			}
-		}
+	var repairs repairLog
+	if result, err := repairPalette(content, &repairs); err == nil {
+		logRepairs(result.Repairs)
+		return result, true
 	}
 
-	colors := extractColors(content)
-	if len(colors) >= 5 {
-		return &PaletteResult{Colors: colors[:5]}, true
+	colors := extractColors(reasoningTagRegex.ReplaceAllString(content, ""))
+	if len(colors) >= paletteSize {
+		result := &PaletteResult{Colors: colors[:paletteSize], Repairs: []string{repairColorsFromText}}
+		logRepairs(result.Repairs)
+		return result, true
 	}
 	return nil, false
 }
@@ -358,8 +524,9 @@ func buildPaletteToolDefinition() ToolDefinition {
 						"minLength":   6,
 						"maxLength":   200,
 					},
+					"details": colorDetailsSchema(5),
 				},
-				"required":             []string{"colors", "advice"},
+				"required":             []string{"colors", "advice", "details"},
 				"additionalProperties": false,
 			},
 		},
@@ -374,28 +541,17 @@ func parseToolCallResult(call ToolCall) (*PaletteResult, error) {
 	if call.Function.Name != paletteToolName {
 		return nil, fmt.Errorf("unexpected tool call function: %s", call.Function.Name)
 	}
-
-	var payload struct {
-		Colors []string `json:"colors"`
-		Advice string   `json:"advice"`
-	}
-
-	if err := json.Unmarshal([]byte(call.Function.Arguments), &payload); err != nil {
-		return nil, fmt.Errorf("parse tool call arguments: %w", err)
+	var repairs repairLog
+	result, err := repairPalette(call.Function.Arguments, &repairs)
+	if err != nil {
+		return nil, err
 	}
+	logRepairs(result.Repairs)
+	return result, nil
+}
 
-	if len(payload.Colors) != 5 {
-		return nil, fmt.Errorf("tool call returned %d colors, expected 5", len(payload.Colors))
+func logRepairs(repairs []string) {
+	if len(repairs) > 0 {
+		log.Printf("[WARN] Repaired model output: %s", strings.Join(repairs, ", "))
 	}
-
-	normalized := make([]string, 0, len(payload.Colors))
-	for _, color := range payload.Colors {
-		candidate := strings.ToUpper(strings.TrimSpace(color))
-		if !strictHexRegex.MatchString(candidate) {
-			return nil, fmt.Errorf("invalid color from tool call: %s", color)
-		}
-		normalized = append(normalized, candidate)
-	}
-
-	return &PaletteResult{Colors: normalized, Advice: strings.TrimSpace(payload.Advice)}, nil
 }
@@ -0,0 +1,136 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ContentPart OpenAI 风格的多段消息内容
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MessageContent 消息内容。仅含文本时序列化为字符串以兼容不支持多段内容的服务商，
+// 含图片时序列化为 content part 数组
+type MessageContent []ContentPart
+
+// TextContent 构造纯文本内容
+func TextContent(text string) MessageContent {
+	return MessageContent{{Type: "text", Text: text}}
+}
+
+// String 拼接所有文本段
+func (m MessageContent) String() string {
+	var texts []string
+	for _, part := range m {
+		if part.Type == "text" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func (m MessageContent) hasImage() bool {
+	for _, part := range m {
+		if part.Type == "image_url" {
+			return true
+		}
+	}
+	return false
+}
+
+func (m MessageContent) MarshalJSON() ([]byte, error) {
+	if !m.hasImage() {
+		return json.Marshal(m.String())
+	}
+	return json.Marshal([]ContentPart(m))
+}
+
+func (m *MessageContent) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = nil
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*m = TextContent(text)
+		return nil
+	}
+	var parts []ContentPart
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return err
+	}
+	*m = parts
+	return nil
+}
+
+// Image 随提示词一起发送给视觉模型的图片
+type Image struct {
+	Data     []byte
+	MimeType string
+}
+
+var supportedImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// NewImage 校验图片类型，data 为原始字节
+func NewImage(data []byte) (*Image, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+	mimeType := http.DetectContentType(data)
+	if !supportedImageTypes[mimeType] {
+		return nil, fmt.Errorf("unsupported image type: %s", mimeType)
+	}
+	return &Image{Data: data, MimeType: mimeType}, nil
+}
+
+// DecodeImage 解析 base64 或 data URL 形式的图片
+func DecodeImage(encoded string) (*Image, error) {
+	encoded = strings.TrimSpace(encoded)
+	if strings.HasPrefix(encoded, "data:") {
+		comma := strings.Index(encoded, ",")
+		if comma < 0 {
+			return nil, fmt.Errorf("invalid data URL")
+		}
+		encoded = encoded[comma+1:]
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 image: %w", err)
+	}
+	return NewImage(data)
+}
+
+// DataURL 返回 base64 data URL
+func (img *Image) DataURL() string {
+	return "data:" + img.MimeType + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+}
+
+// userContent 构造用户消息，有图片时附加 image_url 段
+func userContent(text string, img *Image) MessageContent {
+	content := TextContent(text)
+	if img != nil {
+		content = append(content, ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: img.DataURL()}})
+	}
+	return content
+}
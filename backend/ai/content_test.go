@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ai-color-palette/config"
+)
+
+// onePixelPNG 1×1 的 PNG 图片
+const onePixelPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8BQDwAEhQGAhKmMIQAAAABJRU5ErkJggg=="
+
+func TestTextContentMarshalsAsString(t *testing.T) {
+	data, err := json.Marshal(ChatMessage{Role: "user", Content: TextContent("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(msg["content"]); got != `"hello"` {
+		t.Errorf("content = %s, want plain string \"hello\"", got)
+	}
+}
+
+func TestImageRequestUsesVisionModel(t *testing.T) {
+	type request struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Role    string          `json:"role"`
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	var got []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		got = append(got, req)
+		args, _ := json.Marshal(map[string]interface{}{
+			"colors": []string{"#264653", "#2A9D8F", "#E9C46A", "#F4A261", "#E76F51"},
+			"advice": "ok",
+		})
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{
+				"message": map[string]interface{}{
+					"role": "assistant",
+					"tool_calls": []map[string]interface{}{{
+						"id":       "call_1",
+						"type":     "function",
+						"function": map[string]string{"name": paletteToolName, "arguments": string(args)},
+					}},
+				},
+			}},
+			"usage": map[string]int{"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15},
+		})
+	}))
+	defer server.Close()
+
+	saved := config.AppConfig
+	defer func() { config.AppConfig = saved }()
+	config.AppConfig = &config.Config{
+		AIAPIKey:       "test",
+		AIAPIBaseURL:   server.URL,
+		AIModel:        "text-model",
+		AIVisionModel:  "vision-model",
+		AITimeout:      5,
+		AICapabilities: "*=forced_tool",
+	}
+
+	img, err := DecodeImage(onePixelPNG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateColorPalette("sunset", Options{Image: img}); err != nil {
+		t.Fatalf("GenerateColorPalette with image: %v", err)
+	}
+	if _, err := GenerateColorPalette("sunset", Options{}); err != nil {
+		t.Fatalf("GenerateColorPalette without image: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(got))
+	}
+
+	withImage := got[0]
+	if withImage.Model != "vision-model" {
+		t.Errorf("image request model = %q, want vision-model", withImage.Model)
+	}
+	var parts []ContentPart
+	user := withImage.Messages[len(withImage.Messages)-1]
+	if err := json.Unmarshal(user.Content, &parts); err != nil {
+		t.Fatalf("image request user content is not a part array: %s", user.Content)
+	}
+	var image *ImageURL
+	for _, part := range parts {
+		if part.Type == "image_url" {
+			image = part.ImageURL
+		}
+	}
+	if image == nil || !strings.HasPrefix(image.URL, "data:image/png;base64,") {
+		t.Errorf("image request has no image_url data URL part: %s", user.Content)
+	}
+
+	textOnly := got[1]
+	if textOnly.Model != "text-model" {
+		t.Errorf("text request model = %q, want text-model", textOnly.Model)
+	}
+	for _, msg := range textOnly.Messages {
+		var text string
+		if err := json.Unmarshal(msg.Content, &text); err != nil {
+			t.Errorf("%s content is not a plain string: %s", msg.Role, msg.Content)
+		}
+	}
+}
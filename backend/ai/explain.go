@@ -0,0 +1,196 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"ai-color-palette/config"
+)
+
+const explainToolName = "explain_palette"
+
+// ColorRoles 颜色在配色中可承担的角色
+var ColorRoles = []string{"primary", "secondary", "accent", "neutral"}
+
+// ColorDetail 单个颜色的结构化说明
+type ColorDetail struct {
+	Color string `json:"color"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	// Usage 建议在画面中所占的面积百分比，全部颜色合计为 100
+	Usage     float64 `json:"usage"`
+	Rationale string  `json:"rationale"`
+}
+
+// Explanation 对已有配色的解释
+type Explanation struct {
+	Details []ColorDetail `json:"details"`
+	Advice  string        `json:"advice"`
+}
+
+// ExplainPalette 让模型为外部来源的配色逐色命名、分配角色与用量并说明理由
+func ExplainPalette(colors []string, prompt string) (*Explanation, error) {
+	cfg := config.AppConfig
+	if cfg.AIAPIKey == "" {
+		return nil, fmt.Errorf("AI API key not configured")
+	}
+
+	userPrompt := fmt.Sprintf("请解释这组配色（顺序固定）：%s。", strings.Join(colors, ", "))
+	if strings.TrimSpace(prompt) != "" {
+		userPrompt += "使用场景：" + strings.TrimSpace(prompt)
+	}
+	reqBody := ChatRequest{
+		Model: cfg.AIModel,
+		Messages: []ChatMessage{
+			{Role: "system", Content: TextContent(buildExplainSystemPrompt())},
+			{Role: "user", Content: TextContent(userPrompt)},
+		},
+		Temperature: 0.4,
+		MaxTokens:   800,
+		operation:   "explain",
+	}
+	tool := buildExplainToolDefinition(len(colors))
+
+	message, err := postStructured(cfg, func() ChatRequest {
+		req := reqBody
+		req.Messages = append([]ChatMessage{}, reqBody.Messages...)
+		applyOutputMode(&req, tool, ProfileFor(req.Model).Mode)
+		return req
+	})
+	if err != nil {
+		return nil, err
+	}
+	arguments, ok := structuredArguments(message, explainToolName)
+	if !ok {
+		return nil, fmt.Errorf("AI did not call %s", explainToolName)
+	}
+	var payload struct {
+		Details []ColorDetail `json:"details"`
+		Advice  string        `json:"advice"`
+	}
+	var repairs repairLog
+	if err := json.Unmarshal([]byte(cleanModelText(arguments, &repairs)), &payload); err != nil {
+		return nil, fmt.Errorf("parse explanation arguments: %w", err)
+	}
+	logRepairs(repairs)
+	details := normalizeDetails(colors, payload.Details)
+	if details == nil {
+		return nil, fmt.Errorf("model returned %d details for %d colors", len(payload.Details), len(colors))
+	}
+	log.Println("[INFO] AI explained palette successfully")
+	return &Explanation{Details: details, Advice: strings.TrimSpace(payload.Advice)}, nil
+}
+
+// normalizeDetails 校正模型返回的逐色说明：数量须与颜色一致，颜色以输入为准，
+// 未知角色归为 neutral，用量按比例缩放到合计 100；数量不符时返回 nil
+func normalizeDetails(colors []string, details []ColorDetail) []ColorDetail {
+	if len(details) != len(colors) {
+		return nil
+	}
+	out := make([]ColorDetail, len(details))
+	total := 0.0
+	for i, d := range details {
+		d.Color = colors[i]
+		d.Name = strings.TrimSpace(d.Name)
+		d.Role = strings.ToLower(strings.TrimSpace(d.Role))
+		if !isColorRole(d.Role) {
+			d.Role = "neutral"
+		}
+		d.Usage = math.Max(0, d.Usage)
+		d.Rationale = strings.TrimSpace(d.Rationale)
+		total += d.Usage
+		out[i] = d
+	}
+	for i := range out {
+		if total > 0 {
+			out[i].Usage = math.Round(out[i].Usage/total*1000) / 10
+		} else {
+			out[i].Usage = math.Round(1000/float64(len(out))) / 10
+		}
+	}
+	return out
+}
+
+func isColorRole(role string) bool {
+	for _, r := range ColorRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// colorDetailsSchema 逐色说明数组的 JSON Schema，return_palette 与 explain_palette 共用
+func colorDetailsSchema(n int) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": fmt.Sprintf("按颜色顺序给出 %d 个颜色各自的说明。", n),
+		"minItems":    n,
+		"maxItems":    n,
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "简短而有画面感的颜色名称，如“雾霾蓝”。",
+					"maxLength":   12,
+				},
+				"role": map[string]interface{}{
+					"type":        "string",
+					"description": "颜色在配色中的角色。",
+					"enum":        ColorRoles,
+				},
+				"usage": map[string]interface{}{
+					"type":        "number",
+					"description": "建议在画面中所占面积的百分比，所有颜色合计为 100。",
+					"minimum":     0,
+					"maximum":     100,
+				},
+				"rationale": map[string]interface{}{
+					"type":        "string",
+					"description": "一句话说明选用该颜色的理由或用法。",
+					"maxLength":   40,
+				},
+			},
+			"required":             []string{"name", "role", "usage", "rationale"},
+			"additionalProperties": false,
+		},
+	}
+}
+
+func buildExplainSystemPrompt() string {
+	return `
+你是一个专业的配色设计师。用户会给你一组已有的颜色，你需要逐个解释：
+- name：为颜色起一个简短、有画面感的中文名称
+- role：primary（主色）、secondary（次色）、accent（点缀色）或 neutral（中性色）
+- usage：建议的面积占比，遵循 60/30/10 法则，所有颜色合计 100
+- rationale：一句话说明该颜色的作用
+并给出整体使用建议。只通过调用 explain_palette 函数回复，不要改动颜色。
+`
+}
+
+func buildExplainToolDefinition(n int) ToolDefinition {
+	return ToolDefinition{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        explainToolName,
+			Description: "返回配色中每个颜色的名称、角色、建议用量与理由，以及整体使用建议。",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"details": colorDetailsSchema(n),
+					"advice": map[string]interface{}{
+						"type":        "string",
+						"description": "配色整体使用建议。",
+						"maxLength":   200,
+					},
+				},
+				"required":             []string{"details", "advice"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package ai
+
+import "ai-color-palette/dataviz"
+
+// checkPurpose 校验配色是否满足可视化用途，返回未满足的问题列表
+func checkPurpose(purpose string, colors []string) ([]string, error) {
+	report, err := dataviz.Validate(purpose, colors)
+	if err != nil {
+		return nil, err
+	}
+	return report.Issues, nil
+}
+
+func buildPurposeSystemPrompt(purpose string) string {
+	head := `
+你是一个数据可视化配色专家。用户会描述图表的数据与场景，你需要返回5个精确的HEX颜色代码，并给出在图表中的使用建议。
+你必须通过调用 return_palette 工具函数返回结果，不要输出任何自然语言文本。
+`
+	var rules string
+	switch purpose {
+	case dataviz.Sequential:
+		rules = `这是【顺序配色】，用于表示从低到高的连续数值：
+1. 5个颜色按顺序排列，明度必须【严格单调】变化（从浅到深或从深到浅），相邻两级明度差均匀且明显
+2. 首尾明度跨度要大，最浅接近背景色，最深足以与白色背景形成强对比
+3. 可使用单一色相，或在相邻色相间平滑过渡（如黄→绿→蓝），彩度随明度平稳变化
+4. 不要加入与序列无关的点缀色`
+	case dataviz.Diverging:
+		rules = `这是【发散配色】，用于表示围绕中间值（如零点、平均值）的正负偏离：
+1. 第3个颜色是【中性中点】，接近灰白或浅米色，彩度很低
+2. 第1、2个颜色与第4、5个颜色分属两种明显不同的色相（如蓝与红、紫与绿）
+3. 两侧【对称】：第1与第5、第2与第4个颜色明度相近，越靠近两端越深、越饱和
+4. 明度从两端向中点单调变化`
+	case dataviz.Categorical:
+		rules = `这是【分类配色】，用于区分没有顺序关系的类别：
+1. 5个颜色两两之间要【最大程度可区分】，色相尽量分散
+2. 考虑红绿、蓝黄色盲用户：不要仅靠红绿色相区分，相近色相的颜色要在明度上拉开差距
+3. 颜色的视觉权重相近，避免某一类别过于突出
+4. 不要使用接近白色或黑色的颜色`
+	default:
+		return buildBaseSystemPrompt()
+	}
+	return head + rules + "\n使用建议中说明适合的图表类型与数据映射方式；details 中的理由说明每个颜色对应的数据区间或类别。\n"
+}
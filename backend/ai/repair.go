@@ -0,0 +1,273 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// paletteSize 生成与微调时要求模型返回的颜色数
+const paletteSize = 5
+
+// 修复项名称，记录在 PaletteResult.Repairs 中
+const (
+	repairReasoningTags    = "strip_reasoning_tags"
+	repairCodeFence        = "strip_code_fence"
+	repairEmbeddedJSON     = "extract_embedded_json"
+	repairReasoningContent = "use_reasoning_content"
+	repairColorString      = "split_color_string"
+	repairColorObject      = "unwrap_color_object"
+	repairMissingHash      = "add_missing_hash"
+	repairShortHex         = "expand_short_hex"
+	repairAlphaHex         = "drop_hex_alpha"
+	repairCSSColor         = "convert_css_color"
+	repairDuplicateColors  = "drop_duplicate_colors"
+	repairExtraColors      = "trim_extra_colors"
+	repairColorsFromText   = "extract_colors_from_text"
+)
+
+var (
+	reasoningTagRegex = regexp.MustCompile(`(?is)<(think|thinking|reasoning|reflection)>.*?</(think|thinking|reasoning|reflection)>`)
+	codeFenceRegex    = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*(.*?)\\s*```")
+	bareHexRegex      = regexp.MustCompile(`^#?([0-9A-F]{3}|[0-9A-F]{4}|[0-9A-F]{6}|[0-9A-F]{8})$`)
+)
+
+// outputError 模型输出无法修复，Correction 为下一次请求时告诉模型的具体问题
+type outputError struct {
+	reason     string
+	Correction string
+}
+
+func (e *outputError) Error() string {
+	return e.reason
+}
+
+func newOutputError(correction, format string, args ...interface{}) *outputError {
+	return &outputError{reason: fmt.Sprintf(format, args...), Correction: correction}
+}
+
+// repairLog 按出现顺序记录去重后的修复项
+type repairLog []string
+
+func (r *repairLog) add(name string) {
+	for _, existing := range *r {
+		if existing == name {
+			return
+		}
+	}
+	*r = append(*r, name)
+}
+
+// cleanModelText 去掉推理标签与 markdown 代码块，正文不是 JSON 时截取其中第一个完整的 JSON 对象
+func cleanModelText(text string, repairs *repairLog) string {
+	if cleaned := reasoningTagRegex.ReplaceAllString(text, ""); cleaned != text {
+		repairs.add(repairReasoningTags)
+		text = cleaned
+	}
+	// 推理标签未闭合时丢弃标签之前的全部内容
+	if i := strings.LastIndex(strings.ToLower(text), "</think>"); i >= 0 {
+		repairs.add(repairReasoningTags)
+		text = text[i+len("</think>"):]
+	}
+	if m := codeFenceRegex.FindStringSubmatch(text); m != nil {
+		repairs.add(repairCodeFence)
+		text = m[1]
+	}
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") {
+		if object := firstJSONObject(text); object != "" {
+			repairs.add(repairEmbeddedJSON)
+			text = object
+		}
+	}
+	return text
+}
+
+// firstJSONObject 按括号配对截取文本中第一个可解析的 JSON 对象，忽略字符串内的括号
+func firstJSONObject(text string) string {
+	for start := 0; start < len(text); start++ {
+		if text[start] != '{' {
+			continue
+		}
+		if end := matchBrace(text, start); end > 0 && json.Valid([]byte(text[start:end+1])) {
+			return text[start : end+1]
+		}
+	}
+	return ""
+}
+
+// matchBrace 返回与 start 处左括号配对的右括号下标，没有时返回 -1
+func matchBrace(text string, start int) int {
+	depth, inString, escaped := 0, false, false
+	for i := start; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\' && inString:
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// palettePayload return_palette 的参数，colors 先保留原始 JSON 以兼容各种写法
+type palettePayload struct {
+	Colors  json.RawMessage `json:"colors"`
+	Advice  string          `json:"advice"`
+	Details []ColorDetail   `json:"details"`
+}
+
+// repairPalette 解析并修复 return_palette 的参数文本，可恢复的问题就地修正并记录，
+// 无法恢复时返回 *outputError
+func repairPalette(arguments string, repairs *repairLog) (*PaletteResult, error) {
+	text := cleanModelText(arguments, repairs)
+	var payload palettePayload
+	if err := json.Unmarshal([]byte(text), &payload); err != nil {
+		return nil, newOutputError("参数不是合法的 JSON 对象，请只返回 JSON，不要附加解释或代码块",
+			"parse palette arguments: %v", err)
+	}
+	raw, err := rawColors(payload.Colors, repairs)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := make([]string, 0, len(raw))
+	for _, value := range raw {
+		color, ok := repairColor(value, repairs)
+		if !ok {
+			return nil, newOutputError(fmt.Sprintf("颜色 %q 无法识别，colors 中每一项都必须是 #RRGGBB 格式的 HEX 颜色", value),
+				"invalid color: %s", value)
+		}
+		colors = append(colors, color)
+	}
+	details := payload.Details
+
+	if len(colors) > paletteSize {
+		colors, details = dropDuplicates(colors, details, repairs)
+	}
+	if len(colors) > paletteSize {
+		repairs.add(repairExtraColors)
+		colors = colors[:paletteSize]
+		if len(details) > paletteSize {
+			details = details[:paletteSize]
+		}
+	}
+	if len(colors) < paletteSize {
+		return nil, newOutputError(fmt.Sprintf("colors 只有 %d 个颜色，必须恰好 %d 个", len(colors), paletteSize),
+			"palette has %d colors, expected %d", len(colors), paletteSize)
+	}
+
+	return &PaletteResult{
+		Colors:  colors,
+		Advice:  strings.TrimSpace(payload.Advice),
+		Details: normalizeDetails(colors, details),
+		Repairs: *repairs,
+	}, nil
+}
+
+// rawColors 接受字符串数组、逗号或空白分隔的字符串，以及含 hex/color 字段的对象数组
+func rawColors(data json.RawMessage, repairs *repairLog) ([]string, error) {
+	missing := newOutputError(fmt.Sprintf("缺少 colors 字段，必须是包含 %d 个 #RRGGBB 字符串的数组", paletteSize), "palette has no colors")
+	if len(data) == 0 || string(data) == "null" {
+		return nil, missing
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+	// 类型不符时 Unmarshal 仍会按数组长度填入空串，后续分支需从空列表开始
+	list = nil
+	var joined string
+	if err := json.Unmarshal(data, &joined); err == nil {
+		repairs.add(repairColorString)
+		return strings.FieldsFunc(joined, func(r rune) bool { return r == ',' || r == ';' || r == ' ' || r == '\n' }), nil
+	}
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err == nil {
+		repairs.add(repairColorObject)
+		for _, object := range objects {
+			for _, key := range []string{"hex", "color", "value"} {
+				if v, ok := object[key].(string); ok {
+					list = append(list, v)
+					break
+				}
+			}
+		}
+		return list, nil
+	}
+	return nil, missing
+}
+
+// repairColor 将单个颜色规范为 #RRGGBB：补 #、展开 3/4 位缩写、去掉透明度，或按 CSS 颜色解析
+func repairColor(value string, repairs *repairLog) (string, bool) {
+	candidate := strings.ToUpper(strings.Trim(strings.TrimSpace(value), `"'`))
+	if strictHexRegex.MatchString(candidate) {
+		return candidate, true
+	}
+	if m := bareHexRegex.FindStringSubmatch(candidate); m != nil {
+		if !strings.HasPrefix(candidate, "#") {
+			repairs.add(repairMissingHash)
+		}
+		hex := m[1]
+		switch len(hex) {
+		case 3, 4:
+			repairs.add(repairShortHex)
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		case 8:
+			repairs.add(repairAlphaHex)
+			hex = hex[:6]
+		}
+		return "#" + hex, true
+	}
+	if c, ok := colorutil.ParseCSSColor(value); ok {
+		repairs.add(repairCSSColor)
+		return c.Hex(), true
+	}
+	return "", false
+}
+
+// dropDuplicates 颜色多于要求时先去掉重复项，逐色说明同步去掉
+func dropDuplicates(colors []string, details []ColorDetail, repairs *repairLog) ([]string, []ColorDetail) {
+	seen := make(map[string]bool, len(colors))
+	var keptColors []string
+	var keptDetails []ColorDetail
+	for i, color := range colors {
+		if seen[color] {
+			repairs.add(repairDuplicateColors)
+			continue
+		}
+		seen[color] = true
+		keptColors = append(keptColors, color)
+		if len(details) == len(colors) {
+			keptDetails = append(keptDetails, details[i])
+		}
+	}
+	if len(details) != len(colors) {
+		keptDetails = details
+	}
+	return keptColors, keptDetails
+}
+
+// correctionFor 返回可反馈给模型的修正说明，非输出格式问题时返回空串
+func correctionFor(err error) string {
+	var oe *outputError
+	if errors.As(err, &oe) {
+		return oe.Correction
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const fiveColors = `"#264653","#2A9D8F","#E9C46A","#F4A261","#E76F51"`
+
+var wantColors = []string{"#264653", "#2A9D8F", "#E9C46A", "#F4A261", "#E76F51"}
+
+func TestRepairPalette(t *testing.T) {
+	valid := `{"colors":[` + fiveColors + `],"advice":"ok"}`
+	tests := []struct {
+		name      string
+		arguments string
+		colors    []string
+		repairs   []string
+	}{
+		{"valid", valid, wantColors, nil},
+		{"short hex", `{"colors":["#fff","#000","#E9C46A","#F4A261","#E76F51"]}`,
+			[]string{"#FFFFFF", "#000000", "#E9C46A", "#F4A261", "#E76F51"}, []string{repairShortHex}},
+		{"missing hash", `{"colors":["264653","#2A9D8F","#E9C46A","#F4A261","#E76F51"]}`, wantColors, []string{repairMissingHash}},
+		{"alpha hex", `{"colors":["#264653FF","#2A9D8F","#E9C46A","#F4A261","#E76F51"]}`, wantColors, []string{repairAlphaHex}},
+		{"rgb string", `{"colors":["rgb(38, 70, 83)","#2A9D8F","#E9C46A","#F4A261","#E76F51"]}`, wantColors, []string{repairCSSColor}},
+		{"six colors", `{"colors":[` + fiveColors + `,"#000000"]}`, wantColors, []string{repairExtraColors}},
+		{"duplicate colors", `{"colors":["#264653",` + fiveColors + `]}`, wantColors, []string{repairDuplicateColors}},
+		{"code fence", "```json\n" + valid + "\n```", wantColors, []string{repairCodeFence}},
+		{"reasoning tags", `<think>try {"colors":[]} first</think>` + valid, wantColors, []string{repairReasoningTags}},
+		{"unclosed think", "planning the palette...</think>\n" + valid, wantColors, []string{repairReasoningTags}},
+		{"embedded json", "Here is the palette: " + valid + " Enjoy!", wantColors, []string{repairEmbeddedJSON}},
+		{"color string", `{"colors":"#264653, #2A9D8F, #E9C46A, #F4A261, #E76F51"}`, wantColors, []string{repairColorString}},
+		{"object array", `{"colors":[{"hex":"#264653"},{"color":"#2A9D8F"},{"value":"#E9C46A"},{"hex":"#F4A261"},{"hex":"#E76F51"}]}`,
+			wantColors, []string{repairColorObject}},
+		{"fence and short hex", "```\n" + `{"colors":["#fff","#000","#E9C46A","#F4A261","#E76F51"]}` + "\n```",
+			[]string{"#FFFFFF", "#000000", "#E9C46A", "#F4A261", "#E76F51"}, []string{repairCodeFence, repairShortHex}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var repairs repairLog
+			result, err := repairPalette(tt.arguments, &repairs)
+			if err != nil {
+				t.Fatalf("repairPalette: %v", err)
+			}
+			if !reflect.DeepEqual(result.Colors, tt.colors) {
+				t.Errorf("colors = %v, want %v", result.Colors, tt.colors)
+			}
+			if len(result.Repairs) != 0 || len(tt.repairs) != 0 {
+				if !reflect.DeepEqual(result.Repairs, tt.repairs) {
+					t.Errorf("repairs = %v, want %v", result.Repairs, tt.repairs)
+				}
+			}
+		})
+	}
+}
+
+func TestRepairPaletteCorrection(t *testing.T) {
+	tests := []struct {
+		name       string
+		arguments  string
+		correction string
+	}{
+		{"not json", "I cannot help with that.", "合法的 JSON"},
+		{"missing colors", `{"advice":"ok"}`, "缺少 colors"},
+		{"unknown color", `{"colors":["banana","#2A9D8F","#E9C46A","#F4A261","#E76F51"]}`, `"banana"`},
+		{"too few colors", `{"colors":["#264653","#2A9D8F","#E9C46A","#F4A261"]}`, "只有 4 个颜色"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var repairs repairLog
+			_, err := repairPalette(tt.arguments, &repairs)
+			if err == nil {
+				t.Fatal("repairPalette succeeded, want error")
+			}
+			// 重试时会被包装，Correction 仍应能取出
+			correction := correctionFor(fmt.Errorf("attempt 1: %w", err))
+			if !strings.Contains(correction, tt.correction) {
+				t.Errorf("correction = %q, want it to mention %q", correction, tt.correction)
+			}
+		})
+	}
+
+	if correction := correctionFor(errors.New("connection reset")); correction != "" {
+		t.Errorf("correction for a network error = %q, want empty", correction)
+	}
+}
+
+func TestParsePaletteMessage(t *testing.T) {
+	valid := `{"colors":[` + fiveColors + `],"advice":"ok"}`
+	tests := []struct {
+		name    string
+		message ChatMessage
+		repairs []string
+	}{
+		{"tool call", ChatMessage{ToolCalls: []ToolCall{{Type: "function", Function: ToolCallFunction{Name: paletteToolName, Arguments: valid}}}}, nil},
+		{"content", ChatMessage{Content: TextContent(valid)}, nil},
+		{"reasoning content", ChatMessage{ReasoningContent: valid}, []string{repairReasoningContent}},
+		{"colors in prose", ChatMessage{Content: TextContent("Try #264653, #2A9D8F, #E9C46A, #F4A261 and #E76F51.")}, []string{repairColorsFromText}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parsePaletteMessage(&tt.message)
+			if err != nil {
+				t.Fatalf("parsePaletteMessage: %v", err)
+			}
+			if !reflect.DeepEqual(result.Colors, wantColors) {
+				t.Errorf("colors = %v, want %v", result.Colors, wantColors)
+			}
+			if (len(result.Repairs) != 0 || len(tt.repairs) != 0) && !reflect.DeepEqual(result.Repairs, tt.repairs) {
+				t.Errorf("repairs = %v, want %v", result.Repairs, tt.repairs)
+			}
+		})
+	}
+
+	_, err := parsePaletteMessage(&ChatMessage{Content: TextContent("Sorry, no palette today.")})
+	if correctionFor(err) == "" {
+		t.Errorf("error %v carries no correction", err)
+	}
+}
@@ -0,0 +1,123 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"ai-color-palette/config"
+)
+
+const rolesToolName = "assign_roles"
+
+// RoleAssignment 模型为配色指定的界面角色（值为颜色下标）
+type RoleAssignment struct {
+	Primary   int    `json:"primary"`
+	Secondary int    `json:"secondary"`
+	Accent    int    `json:"accent"`
+	Neutral   int    `json:"neutral"`
+	Reason    string `json:"reason"`
+}
+
+// AssignRoles 让模型为配色中的颜色分配主色、次色、点缀色与中性色
+func AssignRoles(colors []string, prompt string) (*RoleAssignment, error) {
+	cfg := config.AppConfig
+	if cfg.AIAPIKey == "" {
+		return nil, fmt.Errorf("AI API key not configured")
+	}
+
+	var list strings.Builder
+	for i, color := range colors {
+		fmt.Fprintf(&list, "%d: %s\n", i, color)
+	}
+	userPrompt := "配色（下标: 颜色）：\n" + list.String()
+	if strings.TrimSpace(prompt) != "" {
+		userPrompt += "使用场景：" + strings.TrimSpace(prompt)
+	}
+
+	reqBody := ChatRequest{
+		Model: cfg.AIModel,
+		Messages: []ChatMessage{
+			{Role: "system", Content: TextContent(buildRolesSystemPrompt())},
+			{Role: "user", Content: TextContent(userPrompt)},
+		},
+		Temperature: 0.2,
+		MaxTokens:   200,
+		operation:   "assign-roles",
+	}
+	tool := buildRolesToolDefinition(len(colors))
+
+	message, err := postStructured(cfg, func() ChatRequest {
+		req := reqBody
+		req.Messages = append([]ChatMessage{}, reqBody.Messages...)
+		applyOutputMode(&req, tool, ProfileFor(req.Model).Mode)
+		return req
+	})
+	if err != nil {
+		return nil, err
+	}
+	arguments, ok := structuredArguments(message, rolesToolName)
+	if !ok {
+		return nil, fmt.Errorf("AI did not call %s", rolesToolName)
+	}
+	var result RoleAssignment
+	var repairs repairLog
+	if err := json.Unmarshal([]byte(cleanModelText(arguments, &repairs)), &result); err != nil {
+		return nil, fmt.Errorf("parse role arguments: %w", err)
+	}
+	logRepairs(repairs)
+	for _, i := range []int{result.Primary, result.Secondary, result.Accent, result.Neutral} {
+		if i < 0 || i >= len(colors) {
+			return nil, fmt.Errorf("role index %d out of range", i)
+		}
+	}
+	result.Reason = strings.TrimSpace(result.Reason)
+	log.Printf("[INFO] AI assigned roles: %+v", result)
+	return &result, nil
+}
+
+func buildRolesSystemPrompt() string {
+	return `
+你是一个界面设计师。用户会给你一组带下标的颜色，你需要为界面主题指定角色：
+- primary：主色，用于主要按钮、链接等核心交互元素
+- secondary：次色，用于次要按钮、标签等辅助元素
+- accent：点缀色，用于强调、徽标、高亮，通常是最醒目的颜色
+- neutral：中性色，作为背景与表面的基调，通常是最浅或彩度最低的颜色
+不同角色尽量选择不同的颜色。只通过调用 assign_roles 函数回复。
+`
+}
+
+func buildRolesToolDefinition(n int) ToolDefinition {
+	index := func(desc string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "integer",
+			"description": desc,
+			"minimum":     0,
+			"maximum":     n - 1,
+		}
+	}
+	return ToolDefinition{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        rolesToolName,
+			Description: "为配色中的颜色指定界面角色，参数为颜色下标。",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"primary":   index("主色的下标"),
+					"secondary": index("次色的下标"),
+					"accent":    index("点缀色的下标"),
+					"neutral":   index("中性色（背景基调）的下标"),
+					"reason": map[string]interface{}{
+						"type":        "string",
+						"description": "一句话说明分配理由。",
+						"maxLength":   120,
+					},
+				},
+				"required":             []string{"primary", "secondary", "accent", "neutral"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
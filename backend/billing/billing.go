@@ -0,0 +1,309 @@
+// Package billing 记录每次模型调用的 token 用量，按单价折算费用，并在超出月度预算时拒绝新的调用
+package billing
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"ai-color-palette/config"
+)
+
+// ErrBudgetExceeded 本月费用已达到 AI_MONTHLY_BUDGET
+var ErrBudgetExceeded = errors.New("monthly AI budget exceeded")
+
+// monthLayout 账本按自然月划分
+const monthLayout = "2006-01"
+
+// saveDelay 记录用量后延迟写盘，期间的多次调用合并为一次写入
+const saveDelay = 2 * time.Second
+
+// Totals 一组调用的累计用量
+type Totals struct {
+	Requests         int64   `json:"requests"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+	// MissingUsage 服务商未返回用量的请求数，这些请求不计费用
+	MissingUsage int64 `json:"missing_usage,omitempty"`
+	// Unpriced 模型未配置单价的请求数
+	Unpriced int64 `json:"unpriced,omitempty"`
+}
+
+func (t *Totals) add(o Totals) {
+	t.Requests += o.Requests
+	t.PromptTokens += o.PromptTokens
+	t.CompletionTokens += o.CompletionTokens
+	t.TotalTokens += o.TotalTokens
+	t.Cost += o.Cost
+	t.MissingUsage += o.MissingUsage
+	t.Unpriced += o.Unpriced
+}
+
+// Series 同一操作、模型与 API Key 的累计用量
+type Series struct {
+	Operation string `json:"operation"`
+	Model     string `json:"model"`
+	// Key 脱敏后的 API Key
+	Key string `json:"key"`
+	Totals
+}
+
+// ledger 一个自然月的账本
+type ledger struct {
+	Month  string    `json:"month"`
+	Series []*Series `json:"series"`
+}
+
+// find 返回同一操作、模型与 Key 的累计项，没有时新建
+func (l *ledger) find(operation, model, key string) *Series {
+	for _, s := range l.Series {
+		if s.Operation == operation && s.Model == model && s.Key == key {
+			return s
+		}
+	}
+	s := &Series{Operation: operation, Model: model, Key: key}
+	l.Series = append(l.Series, s)
+	return s
+}
+
+var (
+	mu      sync.Mutex
+	loaded  bool
+	prices  map[string]Price
+	ledgers = map[string]*ledger{}
+	// warned 本月已记录过超出预算的日志
+	warned string
+	// savePending 已安排写盘但尚未执行
+	savePending bool
+	// saveMu 保证写盘按顺序进行，不与 mu 同时持有
+	saveMu sync.Mutex
+)
+
+// load 首次使用时读取单价配置与持久化的账本
+func load() {
+	if loaded {
+		return
+	}
+	loaded = true
+	cfg := config.AppConfig
+	var err error
+	if prices, err = parsePricing(cfg.AIPricing); err != nil {
+		log.Printf("[WARN] Ignore AI_PRICING: %v", err)
+		prices = map[string]Price{}
+	}
+	if cfg.AIUsageFile == "" {
+		return
+	}
+	data, err := os.ReadFile(cfg.AIUsageFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Read usage file failed: %v", err)
+		}
+		return
+	}
+	var saved []*ledger
+	if err := json.Unmarshal(data, &saved); err != nil {
+		log.Printf("[WARN] Parse usage file failed: %v", err)
+		return
+	}
+	for _, l := range saved {
+		ledgers[l.Month] = l
+	}
+	log.Printf("[INFO] Loaded usage ledgers for %d months from %s", len(saved), cfg.AIUsageFile)
+}
+
+// scheduleSave 在 saveDelay 后写盘，已安排时不重复安排；调用方需持有 mu
+func scheduleSave() {
+	if config.AppConfig.AIUsageFile == "" || savePending {
+		return
+	}
+	savePending = true
+	time.AfterFunc(saveDelay, save)
+}
+
+// save 把全部账本写回 AI_USAGE_FILE：持有 mu 时只做序列化，文件写入在锁外进行，
+// 先写临时文件再替换以免中途失败损坏原文件
+func save() {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+
+	mu.Lock()
+	savePending = false
+	list := make([]*ledger, 0, len(ledgers))
+	for _, l := range ledgers {
+		list = append(list, l)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Month < list[j].Month })
+	data, err := json.MarshalIndent(list, "", "  ")
+	mu.Unlock()
+	if err != nil {
+		log.Printf("[WARN] Marshal usage ledgers failed: %v", err)
+		return
+	}
+
+	path := config.AppConfig.AIUsageFile
+	tmp := path + ".tmp"
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(tmp, data, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		log.Printf("[WARN] Save usage file failed: %v", err)
+	}
+}
+
+func currentMonth() string {
+	return time.Now().Format(monthLayout)
+}
+
+// Record 记录一次调用的用量，key 为调用所用的原始 API Key，只保存脱敏后的形式
+func Record(operation, model, key string, promptTokens, completionTokens int) {
+	mu.Lock()
+	defer mu.Unlock()
+	load()
+
+	t := Totals{
+		Requests:         1,
+		PromptTokens:     int64(promptTokens),
+		CompletionTokens: int64(completionTokens),
+		TotalTokens:      int64(promptTokens + completionTokens),
+	}
+	if promptTokens == 0 && completionTokens == 0 {
+		t.MissingUsage = 1
+	}
+	if price, ok := priceFor(prices, model); ok {
+		t.Cost = price.Cost(promptTokens, completionTokens)
+	} else {
+		t.Unpriced = 1
+	}
+
+	month := currentMonth()
+	l, ok := ledgers[month]
+	if !ok {
+		l = &ledger{Month: month}
+		ledgers[month] = l
+	}
+	l.find(operation, model, maskKey(key)).add(t)
+	scheduleSave()
+}
+
+// CheckBudget 本月费用达到预算时返回 ErrBudgetExceeded，预算为 0 表示不限
+func CheckBudget() error {
+	budget := config.AppConfig.AIMonthlyBudget
+	if budget <= 0 {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	load()
+	month := currentMonth()
+	if monthTotals(month).Cost < budget {
+		return nil
+	}
+	if warned != month {
+		warned = month
+		log.Printf("[WARN] Monthly AI budget %.4f exhausted for %s, AI calls are disabled until next month", budget, month)
+	}
+	return ErrBudgetExceeded
+}
+
+func monthTotals(month string) Totals {
+	var total Totals
+	if l, ok := ledgers[month]; ok {
+		for _, s := range l.Series {
+			total.add(s.Totals)
+		}
+	}
+	return total
+}
+
+// Report 某月的用量汇总
+type Report struct {
+	Month    string  `json:"month"`
+	Currency string  `json:"currency"`
+	Budget   float64 `json:"budget"`
+	// Remaining 预算余额，未设预算时为 null
+	Remaining      *float64          `json:"remaining"`
+	BudgetExceeded bool              `json:"budget_exceeded"`
+	Total          Totals            `json:"total"`
+	ByOperation    map[string]Totals `json:"by_operation"`
+	ByModel        map[string]Totals `json:"by_model"`
+	ByKey          map[string]Totals `json:"by_key"`
+	Series         []Series          `json:"series"`
+	Pricing        map[string]Price  `json:"pricing"`
+	// Months 有记录的全部月份
+	Months []string `json:"months"`
+}
+
+// MonthlyReport 返回指定月份（YYYY-MM）的汇总，month 为空时为本月
+func MonthlyReport(month string) Report {
+	mu.Lock()
+	defer mu.Unlock()
+	load()
+	cfg := config.AppConfig
+	if month == "" {
+		month = currentMonth()
+	}
+
+	r := Report{
+		Month:       month,
+		Currency:    cfg.AICurrency,
+		Budget:      cfg.AIMonthlyBudget,
+		Total:       monthTotals(month),
+		ByOperation: map[string]Totals{},
+		ByModel:     map[string]Totals{},
+		ByKey:       map[string]Totals{},
+		Series:      []Series{},
+		Pricing:     prices,
+	}
+	if r.Budget > 0 {
+		remaining := r.Budget - r.Total.Cost
+		if remaining < 0 {
+			remaining = 0
+		}
+		r.Remaining = &remaining
+		r.BudgetExceeded = month == currentMonth() && r.Total.Cost >= r.Budget
+	}
+	if l, ok := ledgers[month]; ok {
+		for _, s := range l.Series {
+			r.Series = append(r.Series, *s)
+			addTo(r.ByOperation, s.Operation, s.Totals)
+			addTo(r.ByModel, s.Model, s.Totals)
+			addTo(r.ByKey, s.Key, s.Totals)
+		}
+	}
+	sort.Slice(r.Series, func(i, j int) bool { return r.Series[i].Cost > r.Series[j].Cost })
+	for m := range ledgers {
+		r.Months = append(r.Months, m)
+	}
+	sort.Strings(r.Months)
+	return r
+}
+
+func addTo(group map[string]Totals, name string, t Totals) {
+	total := group[name]
+	total.add(t)
+	group[name] = total
+}
+
+// maskKey 只保留 API Key 的首尾几位，用于区分不同的 Key
+func maskKey(key string) string {
+	switch {
+	case key == "":
+		return "none"
+	case len(key) <= 8:
+		return "****"
+	default:
+		return key[:3] + "…" + key[len(key)-4:]
+	}
+}
@@ -0,0 +1,67 @@
+package billing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"ai-color-palette/config"
+)
+
+// WriteMetrics 以 Prometheus 文本格式输出本月用量，月份切换后序列从零开始，因此均为 gauge
+func WriteMetrics(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	load()
+	month := currentMonth()
+
+	var series []*Series
+	if l, ok := ledgers[month]; ok {
+		for _, s := range l.Series {
+			series = append(series, s)
+		}
+	}
+	sort.Slice(series, func(i, j int) bool {
+		a, b := series[i], series[j]
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return a.Key < b.Key
+	})
+
+	currency := strings.ToLower(config.AppConfig.AICurrency)
+	labels := func(s *Series) string {
+		return fmt.Sprintf("month=%q,operation=%q,model=%q,key=%q", month, s.Operation, s.Model, s.Key)
+	}
+	header(w, "paletteflow_ai_requests", "AI requests this month.")
+	for _, s := range series {
+		fmt.Fprintf(w, "paletteflow_ai_requests{%s} %d\n", labels(s), s.Requests)
+	}
+	header(w, "paletteflow_ai_tokens", "AI tokens this month.")
+	for _, s := range series {
+		fmt.Fprintf(w, "paletteflow_ai_tokens{%s,kind=\"prompt\"} %d\n", labels(s), s.PromptTokens)
+		fmt.Fprintf(w, "paletteflow_ai_tokens{%s,kind=\"completion\"} %d\n", labels(s), s.CompletionTokens)
+	}
+	header(w, "paletteflow_ai_cost", "AI cost this month.")
+	for _, s := range series {
+		fmt.Fprintf(w, "paletteflow_ai_cost{%s,currency=%q} %g\n", labels(s), currency, s.Cost)
+	}
+
+	budget := config.AppConfig.AIMonthlyBudget
+	exceeded := 0
+	if budget > 0 && monthTotals(month).Cost >= budget {
+		exceeded = 1
+	}
+	header(w, "paletteflow_ai_budget", "Monthly AI budget, 0 means unlimited.")
+	fmt.Fprintf(w, "paletteflow_ai_budget{currency=%q} %g\n", currency, budget)
+	header(w, "paletteflow_ai_budget_exceeded", "Whether AI calls are disabled by the monthly budget.")
+	fmt.Fprintf(w, "paletteflow_ai_budget_exceeded %d\n", exceeded)
+}
+
+func header(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
@@ -0,0 +1,58 @@
+package billing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Price 模型每百万 token 的单价
+type Price struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// Cost 按单价计算一次请求的费用
+func (p Price) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.Input + float64(completionTokens)*p.Output) / 1e6
+}
+
+// parsePricing 解析 AI_PRICING，格式为“模型=输入单价/输出单价;…”，单价按每百万 token 计，
+// 模型写 * 时作用于未单独配置的所有模型
+func parsePricing(spec string) (map[string]Price, error) {
+	prices := make(map[string]Price)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, rates, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q has no '='", entry)
+		}
+		input, output, ok := strings.Cut(rates, "/")
+		if !ok {
+			// 只写一个单价时输入输出同价
+			output = input
+		}
+		var p Price
+		var err error
+		if p.Input, err = strconv.ParseFloat(strings.TrimSpace(input), 64); err != nil || p.Input < 0 {
+			return nil, fmt.Errorf("invalid input price in %q", entry)
+		}
+		if p.Output, err = strconv.ParseFloat(strings.TrimSpace(output), 64); err != nil || p.Output < 0 {
+			return nil, fmt.Errorf("invalid output price in %q", entry)
+		}
+		prices[strings.TrimSpace(model)] = p
+	}
+	return prices, nil
+}
+
+// priceFor 返回模型的单价，未配置时返回 false
+func priceFor(prices map[string]Price, model string) (Price, bool) {
+	if p, ok := prices[model]; ok {
+		return p, true
+	}
+	p, ok := prices["*"]
+	return p, ok
+}
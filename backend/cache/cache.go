@@ -0,0 +1,220 @@
+// Package cache 带过期时间的 LRU 缓存，可选以目录中的文件作为二级存储，重启后仍可命中
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pruneInterval 定期清理磁盘中过期文件的间隔，上次运行遗留、未再读入内存的文件也由此删除
+const pruneInterval = time.Hour
+
+// Cache 以字符串为键、JSON 为值的缓存，并发安全
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	dir      string
+	order    *list.List
+	items    map[string]*list.Element
+	hits     int64
+	misses   int64
+}
+
+// entry 缓存项，同时是磁盘文件的内容
+type entry struct {
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Expires time.Time       `json:"expires"`
+}
+
+// Stats 缓存的命中情况
+type Stats struct {
+	Entries int   `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+// New 创建最多保存 capacity 项、每项保存 ttl 的缓存；dir 非空时同时写入该目录，并定期清理其中已过期的文件
+func New(capacity int, ttl time.Duration, dir string) *Cache {
+	c := &Cache{
+		capacity: capacity,
+		ttl:      ttl,
+		dir:      dir,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Printf("[WARN] Create cache dir failed: %v, disk cache disabled", err)
+			c.dir = ""
+		} else {
+			c.pruneDisk()
+			go func() {
+				for range time.Tick(pruneInterval) {
+					c.pruneDisk()
+				}
+			}()
+		}
+	}
+	return c
+}
+
+// Get 读取未过期的缓存项，内存未命中时再查磁盘；文件读写都在锁外进行
+func (c *Cache) Get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		if time.Now().Before(e.Expires) {
+			c.order.MoveToFront(el)
+			c.hits++
+			c.mu.Unlock()
+			return e.Value, true
+		}
+		c.remove(el)
+		c.misses++
+		c.mu.Unlock()
+		c.removeDisk(key)
+		return nil, false
+	}
+	c.mu.Unlock()
+
+	e, ok := c.readDisk(key)
+	c.mu.Lock()
+	var evicted []string
+	if ok {
+		if _, exists := c.items[key]; !exists {
+			evicted = c.insert(e)
+		}
+		c.hits++
+	} else {
+		c.misses++
+	}
+	c.mu.Unlock()
+	c.removeDisk(evicted...)
+	if !ok {
+		return nil, false
+	}
+	return e.Value, true
+}
+
+// Set 写入缓存项，超出容量时淘汰最久未使用的项并删除其磁盘文件
+func (c *Cache) Set(key string, value json.RawMessage) {
+	e := &entry{Key: key, Value: value, Expires: time.Now().Add(c.ttl)}
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	evicted := c.insert(e)
+	c.mu.Unlock()
+	c.writeDisk(e)
+	c.removeDisk(evicted...)
+}
+
+// Stats 返回当前项数与累计命中次数
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Entries: c.order.Len(), Hits: c.hits, Misses: c.misses}
+}
+
+// insert 加入缓存项并返回因超出容量被淘汰的键
+func (c *Cache) insert(e *entry) []string {
+	c.items[e.Key] = c.order.PushFront(e)
+	var evicted []string
+	for c.order.Len() > c.capacity {
+		el := c.order.Back()
+		evicted = append(evicted, el.Value.(*entry).Key)
+		c.remove(el)
+	}
+	return evicted
+}
+
+// remove 从内存中移除，磁盘文件由调用方在锁外删除
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).Key)
+}
+
+// path 以键的 SHA-256 作为文件名，避免提示词中的特殊字符
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) readDisk(key string) (*entry, bool) {
+	if c.dir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var e entry
+	// 文件名碰撞或内容损坏时视为未命中
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return nil, false
+	}
+	if time.Now().After(e.Expires) {
+		c.removeDisk(key)
+		return nil, false
+	}
+	return &e, true
+}
+
+// removeDisk 删除淘汰或过期的缓存项对应的文件
+func (c *Cache) removeDisk(keys ...string) {
+	if c.dir == "" {
+		return
+	}
+	for _, key := range keys {
+		if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+			log.Printf("[WARN] Remove cache file failed: %v", err)
+		}
+	}
+}
+
+func (c *Cache) writeDisk(e *entry) {
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err == nil {
+		err = os.WriteFile(c.path(e.Key), data, 0o644)
+	}
+	if err != nil {
+		log.Printf("[WARN] Write cache file failed: %v", err)
+	}
+}
+
+// pruneDisk 删除目录中已过期或无法解析的缓存文件
+func (c *Cache) pruneDisk() {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	now, removed := time.Now(), 0
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.dir, f.Name())
+		data, err := os.ReadFile(path)
+		var e entry
+		if err != nil || json.Unmarshal(data, &e) != nil || now.After(e.Expires) {
+			os.Remove(path)
+			removed++
+		}
+	}
+	if removed > 0 {
+		log.Printf("[INFO] Removed %d expired cache files from %s", removed, c.dir)
+	}
+}
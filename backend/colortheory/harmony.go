@@ -0,0 +1,288 @@
+// Package colortheory 基于色相环的经典配色方案：识别配色遵循的方案，并以最小的色相旋转使其贴合方案
+package colortheory
+
+import (
+	"fmt"
+	"math"
+
+	"ai-color-palette/colorutil"
+)
+
+// 经典配色方案
+const (
+	Monochromatic      = "monochromatic"
+	Analogous          = "analogous"
+	Complementary      = "complementary"
+	SplitComplementary = "split-complementary"
+	Triadic            = "triadic"
+	Tetradic           = "tetradic"
+	Square             = "square"
+	// Neutral 全部为无彩色时的识别结果，不属于可选的目标方案
+	Neutral = "neutral"
+)
+
+const (
+	// neutralChroma OKLCH 彩度低于此值视为无彩色，色相没有意义，不参与识别与旋转
+	neutralChroma = 0.03
+	// unusedAnchorPenalty 方案中每个未被任何颜色占用的锚点折算的偏差角度，避免锚点越多越容易“贴合”
+	unusedAnchorPenalty = 15.0
+	// DefaultTolerance 调和时锚点两侧允许保留的色相范围，范围内的颜色不旋转
+	DefaultTolerance = 8.0
+)
+
+// scheme 方案在色相环上的锚点，相对基准色相的角度
+type scheme struct {
+	name    string
+	label   string
+	anchors []float64
+}
+
+// schemes 按锚点数量从少到多排列，代价相同时优先更简单的方案
+var schemes = []scheme{
+	{Monochromatic, "单色", []float64{0}},
+	{Complementary, "互补", []float64{0, 180}},
+	{Analogous, "类似色", []float64{-30, 0, 30}},
+	{SplitComplementary, "分裂互补", []float64{0, 150, 210}},
+	{Triadic, "三角", []float64{0, 120, 240}},
+	{Tetradic, "矩形四色", []float64{0, 60, 180, 240}},
+	{Square, "正方四色", []float64{0, 90, 180, 270}},
+}
+
+// Schemes 返回可识别与调和的方案
+func Schemes() []string {
+	names := make([]string, len(schemes))
+	for i, s := range schemes {
+		names[i] = s.name
+	}
+	return names
+}
+
+func findScheme(name string) (scheme, bool) {
+	for _, s := range schemes {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return scheme{}, false
+}
+
+// Fit 配色与某一方案的贴合情况
+type Fit struct {
+	Scheme string `json:"scheme"`
+	// Rotation 方案基准锚点所在的色相角
+	Rotation float64 `json:"rotation"`
+	// Offset 彩色颜色中离所属锚点最远的角度
+	Offset float64 `json:"offset"`
+	// UnusedAnchors 没有颜色落在附近的锚点数
+	UnusedAnchors int `json:"unused_anchors"`
+	// Targets 每个颜色所属锚点的色相角，无彩色为 null
+	Targets []*float64 `json:"targets"`
+}
+
+// Detection 配色的方案识别结果
+type Detection struct {
+	Scheme      string  `json:"scheme"`
+	Offset      float64 `json:"offset"`
+	Description string  `json:"description"`
+	// Hues 每个颜色的 OKLCH 色相角，无彩色为 null
+	Hues []*float64 `json:"hues"`
+	// Candidates 所有方案的贴合情况，按代价从低到高排列
+	Candidates []Fit `json:"candidates"`
+}
+
+// palette 解析后的配色，hues 只包含彩色颜色
+type palette struct {
+	hexes   []string
+	lchs    []colorutil.OKLCH
+	indices []int
+	hues    []float64
+}
+
+func parse(colors []string) (*palette, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette has no colors")
+	}
+	p := &palette{hexes: make([]string, len(colors)), lchs: make([]colorutil.OKLCH, len(colors))}
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		p.hexes[i], p.lchs[i] = c.Hex(), colorutil.ToOKLCH(c)
+		if p.lchs[i].C >= neutralChroma {
+			p.indices = append(p.indices, i)
+			p.hues = append(p.hues, p.lchs[i].H)
+		}
+	}
+	return p, nil
+}
+
+// Detect 识别配色最接近的经典方案，只看彩色颜色的色相
+func Detect(colors []string) (*Detection, error) {
+	p, err := parse(colors)
+	if err != nil {
+		return nil, err
+	}
+	d := &Detection{Hues: make([]*float64, len(colors))}
+	for k, i := range p.indices {
+		h := colorutil.Round(p.hues[k], 1)
+		d.Hues[i] = &h
+	}
+	if len(p.hues) == 0 {
+		d.Scheme, d.Description = Neutral, "全部为无彩色，不构成色相方案"
+		return d, nil
+	}
+
+	for _, s := range schemes {
+		d.Candidates = append(d.Candidates, p.fit(s))
+	}
+	// 稳定插入排序，代价相同时保持方案的原有顺序
+	for i := 1; i < len(d.Candidates); i++ {
+		for j := i; j > 0 && cost(d.Candidates[j]) < cost(d.Candidates[j-1]); j-- {
+			d.Candidates[j], d.Candidates[j-1] = d.Candidates[j-1], d.Candidates[j]
+		}
+	}
+	best := d.Candidates[0]
+	d.Scheme, d.Offset = best.Scheme, best.Offset
+	label := Label(best.Scheme)
+	if best.Offset < 1 {
+		d.Description = fmt.Sprintf("%s配色", label)
+	} else {
+		d.Description = fmt.Sprintf("接近%s配色，偏差 %.0f°", label, best.Offset)
+	}
+	return d, nil
+}
+
+func cost(f Fit) float64 {
+	return f.Offset + unusedAnchorPenalty*float64(f.UnusedAnchors)
+}
+
+// Label 返回方案的中文名称
+func Label(name string) string {
+	if s, ok := findScheme(name); ok {
+		return s.label
+	}
+	return name
+}
+
+// fit 以 0.5° 步长旋转方案，求使最远颜色偏差最小的旋转角，偏差相同时取总偏差更小者
+func (p *palette) fit(s scheme) Fit {
+	bestRotation, bestMax, bestSum := 0.0, math.Inf(1), math.Inf(1)
+	for rotation := 0.0; rotation < 360; rotation += 0.5 {
+		maxDev, sum := 0.0, 0.0
+		for _, h := range p.hues {
+			_, d := nearestAnchor(s, rotation, h)
+			maxDev = math.Max(maxDev, d)
+			sum += d
+		}
+		if maxDev < bestMax-1e-9 || (maxDev < bestMax+1e-9 && sum < bestSum-1e-9) {
+			bestRotation, bestMax, bestSum = rotation, maxDev, sum
+		}
+	}
+
+	f := Fit{
+		Scheme:   s.name,
+		Rotation: bestRotation,
+		Offset:   colorutil.Round(bestMax, 1),
+		Targets:  make([]*float64, len(p.lchs)),
+	}
+	used := make([]bool, len(s.anchors))
+	for k, h := range p.hues {
+		a, _ := nearestAnchor(s, bestRotation, h)
+		used[a] = true
+		target := colorutil.NormalizeHue(bestRotation + s.anchors[a])
+		f.Targets[p.indices[k]] = &target
+	}
+	for _, u := range used {
+		if !u {
+			f.UnusedAnchors++
+		}
+	}
+	return f
+}
+
+// nearestAnchor 返回离色相最近的锚点下标及距离
+func nearestAnchor(s scheme, rotation, hue float64) (int, float64) {
+	best, bestDist := 0, math.Inf(1)
+	for i, a := range s.anchors {
+		if d := colorutil.HueDistance(hue, rotation+a); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best, bestDist
+}
+
+// Harmonization 调和结果
+type Harmonization struct {
+	Colors []string `json:"colors"`
+	Scheme string   `json:"scheme"`
+	// Shifts 每个颜色的色相旋转角度，正值为逆时针
+	Shifts []float64 `json:"shifts"`
+	// Before 调和前的识别结果，After 调和后与目标方案的贴合情况
+	Before *Detection `json:"before"`
+	After  *Fit       `json:"after,omitempty"`
+}
+
+// Harmonize 将彩色颜色的色相旋转到方案锚点两侧 tolerance 度以内，只改色相、保持 OKLCH 明度与彩度；
+// target 为空时使用识别出的最接近方案，无彩色保持不变
+func Harmonize(colors []string, target string, tolerance float64) (*Harmonization, error) {
+	p, err := parse(colors)
+	if err != nil {
+		return nil, err
+	}
+	if tolerance < 0 {
+		return nil, fmt.Errorf("tolerance must not be negative")
+	}
+	before, err := Detect(colors)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		target = before.Scheme
+	}
+
+	h := &Harmonization{Colors: p.hexes, Shifts: make([]float64, len(colors)), Before: before}
+	if target == Neutral {
+		h.Scheme = Neutral
+		return h, nil
+	}
+	s, ok := findScheme(target)
+	if !ok {
+		return nil, fmt.Errorf("unknown scheme: %s", target)
+	}
+	h.Scheme = s.name
+
+	f := p.fit(s)
+	for _, i := range p.indices {
+		lch := p.lchs[i]
+		delta := signedDelta(lch.H, *f.Targets[i])
+		shift := math.Copysign(math.Max(0, math.Abs(delta)-tolerance), delta)
+		if shift == 0 {
+			continue
+		}
+		lch.H = colorutil.NormalizeHue(lch.H + shift)
+		h.Colors[i] = lch.RGB().Hex()
+		h.Shifts[i] = colorutil.Round(shift, 1)
+	}
+	after, err := parse(h.Colors)
+	if err != nil {
+		return nil, err
+	}
+	// 色域映射可能降低彩度，极少数颜色调和后会变为无彩色
+	if len(after.hues) > 0 {
+		fit := after.fit(s)
+		h.After = &fit
+	}
+	return h, nil
+}
+
+// signedDelta 从色相 from 转到 to 的最短带符号角度（-180, 180]
+func signedDelta(from, to float64) float64 {
+	d := math.Mod(to-from, 360)
+	if d > 180 {
+		d -= 360
+	} else if d <= -180 {
+		d += 360
+	}
+	return d
+}
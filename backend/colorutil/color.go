@@ -0,0 +1,164 @@
+package colorutil
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var strictHexRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// RGB 8位sRGB颜色
+type RGB struct {
+	R, G, B uint8
+}
+
+// NormalizeHex 将颜色规范化为大写 #RRGGBB，格式不合法时返回 false
+func NormalizeHex(color string) (string, bool) {
+	candidate := strings.ToUpper(strings.TrimSpace(color))
+	if !strictHexRegex.MatchString(candidate) {
+		return "", false
+	}
+	return candidate, true
+}
+
+// ParseHex 解析 #RRGGBB 格式的颜色
+func ParseHex(color string) (RGB, error) {
+	normalized, ok := NormalizeHex(color)
+	if !ok {
+		return RGB{}, fmt.Errorf("invalid hex color: %s", color)
+	}
+	value, err := strconv.ParseUint(normalized[1:], 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid hex color: %s", color)
+	}
+	return RGB{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value)}, nil
+}
+
+// MustParseHex 解析已校验过的颜色，格式错误时 panic
+func MustParseHex(color string) RGB {
+	c, err := ParseHex(color)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Hex 返回大写 #RRGGBB
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// Floats 返回 0-1 区间的通道值
+func (c RGB) Floats() (float64, float64, float64) {
+	return float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255
+}
+
+func srgbToLinear(channel float64) float64 {
+	if channel <= 0.04045 {
+		return channel / 12.92
+	}
+	return math.Pow((channel+0.055)/1.055, 2.4)
+}
+
+func linearToSrgb(channel float64) float64 {
+	channel = clamp01(channel)
+	if channel <= 0.0031308 {
+		return channel * 12.92
+	}
+	return 1.055*math.Pow(channel, 1/2.4) - 0.055
+}
+
+func clamp01(v float64) float64 {
+	return math.Min(1, math.Max(0, v))
+}
+
+// ToByte 把 0–1 的通道值换算为 0–255，超出范围时截断
+func ToByte(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
+
+// Round 四舍五入到 digits 位小数，用于接口返回的指标
+func Round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
+
+// Linear 返回线性sRGB通道值
+func (c RGB) Linear() (float64, float64, float64) {
+	r, g, b := c.Floats()
+	return srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+}
+
+// FromLinear 由线性sRGB通道值构造颜色（超出色域时截断）
+func FromLinear(r, g, b float64) RGB {
+	return RGB{R: ToByte(linearToSrgb(r)), G: ToByte(linearToSrgb(g)), B: ToByte(linearToSrgb(b))}
+}
+
+// RelativeLuminance WCAG 2.0 相对亮度
+func RelativeLuminance(c RGB) float64 {
+	r, g, b := c.Linear()
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// ContrastRatio WCAG 2.0 对比度，取值 1-21
+func ContrastRatio(a, b RGB) float64 {
+	la := RelativeLuminance(a)
+	lb := RelativeLuminance(b)
+	lighter := math.Max(la, lb)
+	darker := math.Min(la, lb)
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+// ContrastLevel 与前端一致的对比度等级
+func ContrastLevel(ratio float64) string {
+	if ratio >= 7 {
+		return "AAA"
+	}
+	if ratio >= 4.5 {
+		return "AA"
+	}
+	return "FAIL"
+}
+
+var (
+	Black = RGB{0, 0, 0}
+	White = RGB{255, 255, 255}
+)
+
+// BestTextColor 返回在该背景上对比度更高的黑色或白色
+func BestTextColor(background RGB) RGB {
+	if ContrastRatio(background, Black) >= ContrastRatio(background, White) {
+		return Black
+	}
+	return White
+}
+
+// EnsureContrast 在 OKLCH 空间调整明度（保持色相），直到与背景的对比度不低于 minRatio。
+// 无法达到时返回能取得的最高对比度结果。
+func EnsureContrast(color, background RGB, minRatio float64) RGB {
+	if ContrastRatio(color, background) >= minRatio {
+		return color
+	}
+	lch := ToOKLCH(color)
+	step := 0.01
+	if RelativeLuminance(background) > 0.18 {
+		step = -step
+	}
+	best := color
+	for l := lch.L; l >= 0 && l <= 1; l += step {
+		candidate := OKLCH{L: l, C: lch.C, H: lch.H}.RGB()
+		if ContrastRatio(candidate, background) > ContrastRatio(best, background) {
+			best = candidate
+		}
+		if ContrastRatio(candidate, background) >= minRatio {
+			return candidate
+		}
+	}
+	if ContrastRatio(BestTextColor(background), background) > ContrastRatio(best, background) {
+		return BestTextColor(background)
+	}
+	return best
+}
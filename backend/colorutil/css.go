@@ -0,0 +1,84 @@
+package colorutil
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	cssHexRegex  = regexp.MustCompile(`^#([0-9A-Fa-f]{3,4}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$`)
+	cssFuncRegex = regexp.MustCompile(`^(rgba?|hsla?)\(\s*([^)]*)\)$`)
+)
+
+// ParseCSSColor 解析 CSS 颜色值：#RGB/#RGBA/#RRGGBB/#RRGGBBAA、rgb()/rgba()、hsl()/hsla()
+// 以及命名颜色。透明度会被忽略。
+func ParseCSSColor(value string) (RGB, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if hex, ok := CSSNamedColors[value]; ok {
+		return MustParseHex(hex), true
+	}
+	if cssHexRegex.MatchString(value) {
+		hex := value[1:]
+		if len(hex) <= 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		c, err := ParseHex("#" + hex[:6])
+		return c, err == nil
+	}
+
+	m := cssFuncRegex.FindStringSubmatch(value)
+	if m == nil {
+		return RGB{}, false
+	}
+	args := cssArgs(m[2])
+	if len(args) < 3 {
+		return RGB{}, false
+	}
+	if strings.HasPrefix(m[1], "rgb") {
+		var channels [3]float64
+		for i := 0; i < 3; i++ {
+			v, ok := cssNumber(args[i], 255)
+			if !ok {
+				return RGB{}, false
+			}
+			channels[i] = v / 255
+		}
+		return RGB{R: ToByte(channels[0]), G: ToByte(channels[1]), B: ToByte(channels[2])}, true
+	}
+
+	h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+	if err != nil {
+		return RGB{}, false
+	}
+	s, ok1 := cssNumber(args[1], 1)
+	l, ok2 := cssNumber(args[2], 1)
+	if !ok1 || !ok2 || !strings.HasSuffix(args[1], "%") || !strings.HasSuffix(args[2], "%") {
+		return RGB{}, false
+	}
+	return FromHSL(h, s, l), true
+}
+
+// cssArgs 同时支持逗号分隔与 CSS Color 4 的空格加斜杠语法
+func cssArgs(raw string) []string {
+	raw = strings.ReplaceAll(raw, "/", " ")
+	raw = strings.ReplaceAll(raw, ",", " ")
+	return strings.Fields(raw)
+}
+
+// cssNumber 解析数字或百分比，百分比按 scale 换算
+func cssNumber(token string, scale float64) (float64, bool) {
+	if strings.HasSuffix(token, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(token, "%"), 64)
+		if err != nil {
+			return 0, false
+		}
+		return math.Max(0, math.Min(scale, v/100*scale)), true
+	}
+	v, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, false
+	}
+	return math.Max(0, math.Min(scale, v)), true
+}
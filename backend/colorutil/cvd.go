@@ -0,0 +1,42 @@
+package colorutil
+
+// 色盲模拟矩阵，与前端 colorUtils.js 保持一致（作用于线性 sRGB）
+var cvdMatrices = map[string][3][3]float64{
+	"deuteranopia": {
+		{0.625, 0.375, 0},
+		{0.7, 0.3, 0},
+		{0, 0.3, 0.7},
+	},
+	"protanopia": {
+		{0.56667, 0.43333, 0},
+		{0.55833, 0.44167, 0},
+		{0, 0.24167, 0.75833},
+	},
+	"tritanopia": {
+		{0.95, 0.05, 0},
+		{0, 0.43333, 0.56667},
+		{0, 0.475, 0.525},
+	},
+	"achromatopsia": {
+		{0.299, 0.587, 0.114},
+		{0.299, 0.587, 0.114},
+		{0.299, 0.587, 0.114},
+	},
+}
+
+// CVDTypes 支持的色觉缺陷类型，顺序固定
+var CVDTypes = []string{"deuteranopia", "protanopia", "tritanopia", "achromatopsia"}
+
+// SimulateCVD 模拟指定色觉缺陷下看到的颜色，未知类型原样返回
+func SimulateCVD(c RGB, kind string) RGB {
+	m, ok := cvdMatrices[kind]
+	if !ok {
+		return c
+	}
+	r, g, b := c.Linear()
+	return FromLinear(
+		m[0][0]*r+m[0][1]*g+m[0][2]*b,
+		m[1][0]*r+m[1][1]*g+m[1][2]*b,
+		m[2][0]*r+m[2][1]*g+m[2][2]*b,
+	)
+}
@@ -0,0 +1,86 @@
+package colorutil
+
+import "math"
+
+// DeltaE2000 CIEDE2000 色差，约 1 为刚可察觉差异，大于 5 为明显不同
+func DeltaE2000(c1, c2 Lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+	rad := math.Pi / 180
+
+	cab := (math.Hypot(c1.A, c1.B) + math.Hypot(c2.A, c2.B)) / 2
+	cab7 := math.Pow(cab, 7)
+	g := 0.5 * (1 - math.Sqrt(cab7/(cab7+pow25to7)))
+	a1, a2 := (1+g)*c1.A, (1+g)*c2.A
+	cp1, cp2 := math.Hypot(a1, c1.B), math.Hypot(a2, c2.B)
+	hp := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		return NormalizeHue(math.Atan2(b, a) / rad)
+	}
+	hp1, hp2 := hp(c1.B, a1), hp(c2.B, a2)
+
+	dL := c2.L - c1.L
+	dC := cp2 - cp1
+	var dh float64
+	if cp1*cp2 != 0 {
+		dh = hp2 - hp1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(cp1*cp2) * math.Sin(dh/2*rad)
+
+	lMean := (c1.L + c2.L) / 2
+	cMean := (cp1 + cp2) / 2
+	hMean := hp1 + hp2
+	if cp1*cp2 != 0 {
+		if math.Abs(hp1-hp2) > 180 {
+			if hMean < 360 {
+				hMean += 360
+			} else {
+				hMean -= 360
+			}
+		}
+		hMean /= 2
+	}
+
+	t := 1 - 0.17*math.Cos((hMean-30)*rad) + 0.24*math.Cos(2*hMean*rad) +
+		0.32*math.Cos((3*hMean+6)*rad) - 0.20*math.Cos((4*hMean-63)*rad)
+	dTheta := 30 * math.Exp(-math.Pow((hMean-275)/25, 2))
+	cMean7 := math.Pow(cMean, 7)
+	rc := 2 * math.Sqrt(cMean7/(cMean7+pow25to7))
+	sl := 1 + 0.015*math.Pow(lMean-50, 2)/math.Sqrt(20+math.Pow(lMean-50, 2))
+	sc := 1 + 0.045*cMean
+	sh := 1 + 0.015*cMean*t
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	return math.Sqrt(math.Pow(dL/sl, 2) + math.Pow(dC/sc, 2) + math.Pow(dH/sh, 2) + rt*(dC/sc)*(dH/sh))
+}
+
+// DeltaE 直接比较两个 sRGB 颜色的 CIEDE2000 色差
+func DeltaE(a, b RGB) float64 {
+	return DeltaE2000(ToLab(a), ToLab(b))
+}
+
+// PaletteDistance 两组颜色的对称平均最近色差：每个颜色取另一组中最接近颜色的 ΔE2000，
+// 两个方向分别平均后再取平均，与颜色顺序无关
+func PaletteDistance(a, b []RGB) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	nearest := func(from, to []RGB) float64 {
+		sum := 0.0
+		for _, x := range from {
+			best := math.Inf(1)
+			for _, y := range to {
+				best = math.Min(best, DeltaE(x, y))
+			}
+			sum += best
+		}
+		return sum / float64(len(from))
+	}
+	return (nearest(a, b) + nearest(b, a)) / 2
+}
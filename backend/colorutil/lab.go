@@ -0,0 +1,124 @@
+package colorutil
+
+import "math"
+
+// Lab CIE L*a*b*（D65 白点）
+type Lab struct {
+	L, A, B float64
+}
+
+const (
+	d65X = 0.95047
+	d65Y = 1.0
+	d65Z = 1.08883
+)
+
+func labF(t float64) float64 {
+	if t > 216.0/24389 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27*t + 16) / 116
+}
+
+func labFInv(t float64) float64 {
+	if t*t*t > 216.0/24389 {
+		return t * t * t
+	}
+	return (116*t - 16) / (24389.0 / 27)
+}
+
+// ToLab 将 sRGB 颜色转换为 CIE Lab
+func ToLab(c RGB) Lab {
+	r, g, b := c.Linear()
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / d65X
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*b) / d65Y
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / d65Z
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return Lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// RGB 将 CIE Lab 转换回 sRGB，超出色域的通道直接截断
+func (l Lab) RGB() RGB {
+	fy := (l.L + 16) / 116
+	fx := fy + l.A/500
+	fz := fy - l.B/200
+	x := labFInv(fx) * d65X
+	y := labFInv(fy) * d65Y
+	z := labFInv(fz) * d65Z
+	return FromLinear(
+		3.2404542*x-1.5371385*y-0.4985314*z,
+		-0.9692660*x+1.8760108*y+0.0415560*z,
+		0.0556434*x-0.2040259*y+1.0572252*z,
+	)
+}
+
+// FromCMYK 朴素的 CMYK（0-1）到 sRGB 转换，不考虑印刷特性文件
+func FromCMYK(c, m, y, k float64) RGB {
+	return RGB{
+		R: ToByte((1 - c) * (1 - k)),
+		G: ToByte((1 - m) * (1 - k)),
+		B: ToByte((1 - y) * (1 - k)),
+	}
+}
+
+// FromHSV 由 HSV（均为 0-1）构造颜色
+func FromHSV(h, s, v float64) RGB {
+	h = math.Mod(h, 1)
+	if h < 0 {
+		h++
+	}
+	i := math.Floor(h * 6)
+	f := h*6 - i
+	p := v * (1 - s)
+	q := v * (1 - f*s)
+	t := v * (1 - (1-f)*s)
+	var r, g, b float64
+	switch int(i) % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return RGB{R: ToByte(r), G: ToByte(g), B: ToByte(b)}
+}
+
+// FromHSL 由 HSL 构造颜色，h 为角度，s、l 为 0-1
+func FromHSL(h, s, l float64) RGB {
+	h = NormalizeHue(h) / 360
+	if s == 0 {
+		return RGB{R: ToByte(l), G: ToByte(l), B: ToByte(l)}
+	}
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hueToRGB := func(t float64) float64 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return p + (q-p)*6*t
+		case t < 0.5:
+			return q
+		case t < 2.0/3:
+			return p + (q-p)*(2.0/3-t)*6
+		}
+		return p
+	}
+	return RGB{R: ToByte(hueToRGB(h + 1.0/3)), G: ToByte(hueToRGB(h)), B: ToByte(hueToRGB(h - 1.0/3))}
+}
@@ -0,0 +1,119 @@
+package colorutil
+
+import "math"
+
+// OKLab 感知均匀色彩空间，L 取值 0-1
+type OKLab struct {
+	L, A, B float64
+}
+
+// OKLCH OKLab 的极坐标形式，H 为角度（0-360）
+type OKLCH struct {
+	L, C, H float64
+}
+
+// ToOKLab 将 sRGB 颜色转换为 OKLab
+func ToOKLab(c RGB) OKLab {
+	r, g, b := c.Linear()
+	return linearToOKLab(r, g, b)
+}
+
+func linearToOKLab(r, g, b float64) OKLab {
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// Linear 返回对应的线性 sRGB 值（可能超出 0-1）
+func (o OKLab) Linear() (float64, float64, float64) {
+	l := o.L + 0.3963377774*o.A + 0.2158037573*o.B
+	m := o.L - 0.1055613458*o.A - 0.0638541728*o.B
+	s := o.L - 0.0894841775*o.A - 1.2914855480*o.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+	return 4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+}
+
+// InGamut 判断颜色是否位于 sRGB 色域内
+func (o OKLab) InGamut() bool {
+	const eps = 1e-4
+	r, g, b := o.Linear()
+	return r >= -eps && r <= 1+eps && g >= -eps && g <= 1+eps && b >= -eps && b <= 1+eps
+}
+
+// RGB 转换回 sRGB，超出色域的通道直接截断
+func (o OKLab) RGB() RGB {
+	return FromLinear(o.Linear())
+}
+
+// LCH 转换为极坐标形式
+func (o OKLab) LCH() OKLCH {
+	h := math.Atan2(o.B, o.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: o.L, C: math.Hypot(o.A, o.B), H: h}
+}
+
+// Lab 转换回直角坐标形式
+func (o OKLCH) Lab() OKLab {
+	rad := o.H * math.Pi / 180
+	return OKLab{L: o.L, A: o.C * math.Cos(rad), B: o.C * math.Sin(rad)}
+}
+
+// ToOKLCH 将 sRGB 颜色转换为 OKLCH
+func ToOKLCH(c RGB) OKLCH {
+	return ToOKLab(c).LCH()
+}
+
+// RGB 转换回 sRGB，超出色域时保持明度与色相、降低彩度
+func (o OKLCH) RGB() RGB {
+	o.L = math.Min(1, math.Max(0, o.L))
+	if o.Lab().InGamut() {
+		return o.Lab().RGB()
+	}
+	lo, hi := 0.0, o.C
+	for i := 0; i < 20; i++ {
+		mid := (lo + hi) / 2
+		if (OKLCH{L: o.L, C: mid, H: o.H}).Lab().InGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return OKLCH{L: o.L, C: lo, H: o.H}.Lab().RGB()
+}
+
+// HueDistance 两个色相角之间的最短距离（0-180）
+func HueDistance(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// NormalizeHue 将角度规范到 0-360
+func NormalizeHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// Mix 在 OKLab 空间按 t（0-1）混合两个颜色
+func Mix(a, b RGB, t float64) RGB {
+	la, lb := ToOKLab(a), ToOKLab(b)
+	return OKLab{
+		L: la.L + (lb.L-la.L)*t,
+		A: la.A + (lb.A-la.A)*t,
+		B: la.B + (lb.B-la.B)*t,
+	}.RGB()
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,7 +13,31 @@ type Config struct {
 	AIAPIKey     string
 	AIAPIBaseURL string
 	AIModel      string
-	AITimeout    int
+	// AIVisionModel 请求附带图片时使用的模型，为空时沿用 AIModel
+	AIVisionModel string
+	AITimeout     int
+	// AISupportsN 服务商是否支持 n 参数一次返回多个候选
+	AISupportsN bool
+	// AICapabilities 按模型声明的能力，如 "*=forced_tool;deepseek-reasoner=json_object,n"
+	AICapabilities string
+	// AIProbeCapabilities 未声明能力的模型首次使用时发送探测请求
+	AIProbeCapabilities bool
+	// AIPricing 每百万 token 的单价，如 "gpt-4o-mini=0.15/0.6;*=1/2"
+	AIPricing string
+	// AICurrency 单价与预算使用的货币，仅用于展示
+	AICurrency string
+	// AIMonthlyBudget 每月费用上限，达到后改用离线生成，为 0 时不限
+	AIMonthlyBudget float64
+	// AIUsageFile 用量账本的持久化文件，为空时只保存在内存中
+	AIUsageFile string
+	// AdminToken 访问用量与监控接口所需的令牌，为空时这些接口拒绝访问
+	AdminToken string
+	// AICacheSize 内存中缓存的配色结果数，为 0 时关闭缓存
+	AICacheSize int
+	// AICacheTTL 缓存结果的有效期
+	AICacheTTL time.Duration
+	// AICacheDir 缓存的磁盘目录，为空时只缓存在内存中
+	AICacheDir string
 }
 
 var AppConfig *Config
@@ -31,10 +56,34 @@ func LoadConfig() {
 	}
 
 	AppConfig = &Config{
-		AIAPIKey:     os.Getenv("AI_API_KEY"),
-		AIAPIBaseURL: getEnvOrDefault("AI_API_BASE_URL", "https://open.bigmodel.cn/api/paas/v4"),
-		AIModel:      getEnvOrDefault("AI_MODEL", "glm-4.7-flash"),
-		AITimeout:    timeout,
+		AIAPIKey:       os.Getenv("AI_API_KEY"),
+		AIAPIBaseURL:   getEnvOrDefault("AI_API_BASE_URL", "https://open.bigmodel.cn/api/paas/v4"),
+		AIModel:        getEnvOrDefault("AI_MODEL", "glm-4.7-flash"),
+		AIVisionModel:  os.Getenv("AI_VISION_MODEL"),
+		AITimeout:      timeout,
+		AICapabilities: os.Getenv("AI_CAPABILITIES"),
+		AIPricing:      os.Getenv("AI_PRICING"),
+		AICurrency:     getEnvOrDefault("AI_CURRENCY", "USD"),
+		AIUsageFile:    os.Getenv("AI_USAGE_FILE"),
+		AdminToken:     os.Getenv("ADMIN_TOKEN"),
+		AICacheSize:    256,
+		AICacheTTL:     24 * time.Hour,
+		AICacheDir:     os.Getenv("AI_CACHE_DIR"),
+	}
+	if supportsN, err := strconv.ParseBool(os.Getenv("AI_SUPPORTS_N")); err == nil {
+		AppConfig.AISupportsN = supportsN
+	}
+	if probe, err := strconv.ParseBool(os.Getenv("AI_PROBE_CAPABILITIES")); err == nil {
+		AppConfig.AIProbeCapabilities = probe
+	}
+	if budget, err := strconv.ParseFloat(os.Getenv("AI_MONTHLY_BUDGET"), 64); err == nil && budget > 0 {
+		AppConfig.AIMonthlyBudget = budget
+	}
+	if size, err := strconv.Atoi(os.Getenv("AI_CACHE_SIZE")); err == nil && size >= 0 {
+		AppConfig.AICacheSize = size
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("AI_CACHE_TTL")); err == nil {
+		AppConfig.AICacheTTL = ttl
 	}
 
 	if AppConfig.AIAPIKey == "" {
@@ -0,0 +1,207 @@
+// Package dataviz 数据可视化用途的配色：顺序、发散、分类三种模式的校验与离线生成
+package dataviz
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	Sequential  = "sequential"
+	Diverging   = "diverging"
+	Categorical = "categorical"
+)
+
+// 校验阈值
+const (
+	minSequentialStep  = 0.04 // 顺序配色相邻两级的最小 OKLCH 明度差
+	minSequentialRange = 0.3  // 顺序配色首尾的最小明度跨度
+	maxMidpointChroma  = 0.06 // 发散配色中点允许的最大彩度
+	maxSymmetryDelta   = 0.08 // 发散配色对称位置允许的最大明度差
+	minArmHueDistance  = 60.0 // 发散配色两臂的最小色相差
+	minCategoricalDE   = 15.0 // 分类配色两两之间的最小 ΔE2000
+	minCategoricalCVD  = 8.0  // 色盲模拟下两两之间的最小 ΔE2000
+)
+
+// cvdChecks 分类配色需区分的色觉缺陷类型（全色盲无法依赖色相区分，不做要求）
+var cvdChecks = []string{"deuteranopia", "protanopia", "tritanopia"}
+
+// Purposes 支持的可视化用途
+var Purposes = []string{Sequential, Diverging, Categorical}
+
+// IsPurpose 判断是否为支持的可视化用途
+func IsPurpose(purpose string) bool {
+	for _, p := range Purposes {
+		if p == purpose {
+			return true
+		}
+	}
+	return false
+}
+
+// Report 配色相对用途的校验结果
+type Report struct {
+	Purpose      string             `json:"purpose"`
+	Pass         bool               `json:"pass"`
+	Issues       []string           `json:"issues,omitempty"`
+	Lightness    []float64          `json:"lightness"`
+	MinDeltaE    float64            `json:"min_delta_e"`
+	MinDeltaECVD map[string]float64 `json:"min_delta_e_cvd,omitempty"`
+}
+
+// Validate 按用途检查配色：顺序配色明度单调，发散配色围绕中性中点对称，
+// 分类配色在常规视觉与色盲模拟下都足够可区分
+func Validate(purpose string, colors []string) (*Report, error) {
+	if !IsPurpose(purpose) {
+		return nil, fmt.Errorf("unknown purpose: %s", purpose)
+	}
+	if len(colors) < 2 {
+		return nil, fmt.Errorf("palette needs at least 2 colors")
+	}
+	rgbs := make([]colorutil.RGB, len(colors))
+	lchs := make([]colorutil.OKLCH, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		rgbs[i], lchs[i] = c, colorutil.ToOKLCH(c)
+	}
+
+	r := &Report{Purpose: purpose, Lightness: make([]float64, len(lchs))}
+	for i, lch := range lchs {
+		r.Lightness[i] = colorutil.Round(lch.L, 3)
+	}
+	r.MinDeltaE = colorutil.Round(minDeltaE(rgbs), 1)
+
+	switch purpose {
+	case Sequential:
+		r.Issues = checkSequential(lchs)
+	case Diverging:
+		r.Issues = checkDiverging(lchs)
+	case Categorical:
+		r.MinDeltaECVD = make(map[string]float64, len(cvdChecks))
+		if r.MinDeltaE < minCategoricalDE {
+			r.Issues = append(r.Issues, fmt.Sprintf("颜色两两最小 ΔE2000 为 %.1f，低于 %.0f，部分类别难以区分", r.MinDeltaE, minCategoricalDE))
+		}
+		for _, kind := range cvdChecks {
+			simulated := make([]colorutil.RGB, len(rgbs))
+			for i, c := range rgbs {
+				simulated[i] = colorutil.SimulateCVD(c, kind)
+			}
+			d := colorutil.Round(minDeltaE(simulated), 1)
+			r.MinDeltaECVD[kind] = d
+			if d < minCategoricalCVD {
+				r.Issues = append(r.Issues, fmt.Sprintf("%s 模拟下最小 ΔE2000 为 %.1f，低于 %.0f", kind, d, minCategoricalCVD))
+			}
+		}
+	}
+	r.Pass = len(r.Issues) == 0
+	return r, nil
+}
+
+func checkSequential(lchs []colorutil.OKLCH) []string {
+	var issues []string
+	dir := math.Copysign(1, lchs[len(lchs)-1].L-lchs[0].L)
+	for i := 1; i < len(lchs); i++ {
+		step := (lchs[i].L - lchs[i-1].L) * dir
+		if step < minSequentialStep {
+			issues = append(issues, fmt.Sprintf("第%d到第%d个颜色明度未单调变化（差值 %.3f，至少 %.2f）", i, i+1, step, minSequentialStep))
+		}
+	}
+	if span := math.Abs(lchs[len(lchs)-1].L - lchs[0].L); span < minSequentialRange {
+		issues = append(issues, fmt.Sprintf("首尾明度跨度 %.2f 过小，至少 %.2f", span, minSequentialRange))
+	}
+	return issues
+}
+
+func checkDiverging(lchs []colorutil.OKLCH) []string {
+	n := len(lchs)
+	if n%2 == 0 {
+		return []string{"发散配色需要奇数个颜色，中间一个作为中性中点"}
+	}
+	var issues []string
+	mid := n / 2
+	if lchs[mid].C > maxMidpointChroma {
+		issues = append(issues, fmt.Sprintf("中点彩度 %.3f 过高，应为接近中性的颜色（≤ %.2f）", lchs[mid].C, maxMidpointChroma))
+	}
+
+	// 中点应是明度的极值，两臂从两端向中点单调变化
+	dir := math.Copysign(1, lchs[mid].L-lchs[0].L)
+	for i := 1; i <= mid; i++ {
+		if (lchs[i].L-lchs[i-1].L)*dir <= 0 {
+			issues = append(issues, fmt.Sprintf("左臂第%d到第%d个颜色明度未向中点单调变化", i, i+1))
+		}
+		j := n - 1 - i
+		if (lchs[j].L-lchs[j+1].L)*dir <= 0 {
+			issues = append(issues, fmt.Sprintf("右臂第%d到第%d个颜色明度未向中点单调变化", j+2, j+1))
+		}
+	}
+	for i := 0; i < mid; i++ {
+		if d := math.Abs(lchs[i].L - lchs[n-1-i].L); d > maxSymmetryDelta {
+			issues = append(issues, fmt.Sprintf("第%d与第%d个颜色明度相差 %.2f，两侧不对称", i+1, n-i, d))
+		}
+	}
+	if d := colorutil.HueDistance(lchs[0].H, lchs[n-1].H); d < minArmHueDistance || lchs[0].C < 0.05 || lchs[n-1].C < 0.05 {
+		issues = append(issues, "两端颜色色相应明显不同且有足够彩度，以区分正负两侧")
+	}
+	return issues
+}
+
+func minDeltaE(rgbs []colorutil.RGB) float64 {
+	best := math.Inf(1)
+	for i := range rgbs {
+		for j := i + 1; j < len(rgbs); j++ {
+			best = math.Min(best, colorutil.DeltaE(rgbs[i], rgbs[j]))
+		}
+	}
+	return best
+}
+
+// Fallback 离线生成符合用途的配色，相同 seed 结果相同；用于 AI 不可用时的降级
+func Fallback(purpose string, count int, seed string) []string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.TrimSpace(seed)))
+	hue := float64(h.Sum32() % 360)
+
+	colors := make([]string, count)
+	t := func(i int) float64 {
+		if count == 1 {
+			return 0
+		}
+		return float64(i) / float64(count-1)
+	}
+	switch purpose {
+	case Sequential:
+		// 由浅到深，彩度渐增，色相轻微偏转形成多色相渐变
+		for i := range colors {
+			colors[i] = colorutil.OKLCH{L: 0.93 - 0.58*t(i), C: 0.04 + 0.1*t(i), H: colorutil.NormalizeHue(hue - 25*t(i))}.RGB().Hex()
+		}
+	case Diverging:
+		// 两端为互补色相，向中间变浅并褪为中性
+		for i := range colors {
+			d := math.Abs(2*t(i) - 1)
+			armHue := hue
+			if t(i) > 0.5 {
+				armHue = colorutil.NormalizeHue(hue + 180)
+			}
+			colors[i] = colorutil.OKLCH{L: 0.95 - 0.5*d, C: 0.01 + 0.14*d, H: armHue}.RGB().Hex()
+		}
+	case Categorical:
+		// 按黄金角分布色相，明度交替以便色盲模拟下仍可区分
+		for i := range colors {
+			l := 0.55
+			if i%2 == 1 {
+				l = 0.75
+			}
+			colors[i] = colorutil.OKLCH{L: l, C: 0.14, H: colorutil.NormalizeHue(hue + 137.5*float64(i))}.RGB().Hex()
+		}
+	default:
+		return nil
+	}
+	return colors
+}
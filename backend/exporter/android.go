@@ -0,0 +1,36 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// exportAndroid 生成 res/values/colors.xml，有深色变体时打包 values-night
+func exportAndroid(p *Palette) (*File, error) {
+	light := androidColorsXML(p.uniqueNames(snakeCase), p.Colors)
+	if !p.hasDark() {
+		return &File{Name: "colors.xml", ContentType: "application/xml", Data: light}, nil
+	}
+
+	dark := androidColorsXML(p.uniqueNames(snakeCase), p.DarkColors)
+	data, err := zipEntries([]zipEntry{
+		{Path: "res/values/colors.xml", Data: light},
+		{Path: "res/values-night/colors.xml", Data: dark},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &File{Name: "android-colors.zip", ContentType: "application/zip", Data: data}, nil
+}
+
+func androidColorsXML(names, colors []string) []byte {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	b.WriteString("<!-- Generated by PaletteFlow -->\n")
+	b.WriteString("<resources>\n")
+	for i, color := range colors {
+		fmt.Fprintf(&b, "    <color name=\"palette_%s\">%s</color>\n", names[i], color)
+	}
+	b.WriteString("</resources>\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,147 @@
+package exporter
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// BundleInfo 打包时附带的元信息
+type BundleInfo struct {
+	ID          string
+	Description string
+	CreatedAt   time.Time
+}
+
+type manifestFile struct {
+	Path        string `json:"path"`
+	Format      string `json:"format"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	SHA256      string `json:"sha256"`
+}
+
+type bundleManifest struct {
+	ID          string         `json:"id"`
+	Generator   string         `json:"generator"`
+	Prompt      string         `json:"prompt"`
+	Advice      string         `json:"advice"`
+	Description string         `json:"description"`
+	Colors      []string       `json:"colors"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Files       []manifestFile `json:"files"`
+}
+
+// Bundle 打包前已生成好的全部文件，写出时才压缩
+type Bundle struct {
+	names []string
+	files map[string][]byte
+}
+
+// NewBundle 依次运行所有导出格式并生成 README 与 manifest.json；任一格式失败时返回错误，
+// 调用方可在发送响应头之前得知
+func NewBundle(p *Palette, info BundleInfo) (*Bundle, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	b := &Bundle{files: make(map[string][]byte)}
+	manifest := bundleManifest{
+		ID:          info.ID,
+		Generator:   "PaletteFlow",
+		Prompt:      p.Prompt,
+		Advice:      p.Advice,
+		Description: info.Description,
+		Colors:      p.Colors,
+		CreatedAt:   info.CreatedAt,
+	}
+
+	readme := []byte(bundleReadme(p, info))
+	b.add("README.md", readme)
+	manifest.Files = append(manifest.Files, describeFile("README.md", "readme", "text/markdown; charset=utf-8", readme))
+
+	for _, format := range Formats() {
+		file, err := exporters[format](p)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", format, err)
+		}
+		filePath := path.Join(format, file.Name)
+		b.add(filePath, file.Data)
+		manifest.Files = append(manifest.Files, describeFile(filePath, format, file.ContentType, file.Data))
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	b.add("manifest.json", data)
+	return b, nil
+}
+
+func (b *Bundle) add(name string, data []byte) {
+	b.names = append(b.names, name)
+	b.files[name] = data
+}
+
+// Write 以 zip 流式写入 w，每个文件压缩后立即写出，不在内存中缓存整个压缩包
+func (b *Bundle) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, name := range b.names {
+		if err := writeZipFile(zw, name, b.files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+func describeFile(filePath, format, contentType string, data []byte) manifestFile {
+	sum := sha256.Sum256(data)
+	return manifestFile{
+		Path:        filePath,
+		Format:      format,
+		ContentType: contentType,
+		Size:        len(data),
+		SHA256:      hex.EncodeToString(sum[:]),
+	}
+}
+
+func bundleReadme(p *Palette, info BundleInfo) string {
+	var b strings.Builder
+	b.WriteString("# PaletteFlow Palette\n\n")
+	if info.ID != "" {
+		fmt.Fprintf(&b, "- ID: `%s`\n", info.ID)
+	}
+	if !info.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, "- Created: %s\n", info.CreatedAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "- Colors: %s\n", strings.Join(p.Colors, ", "))
+	if p.Prompt != "" {
+		fmt.Fprintf(&b, "\n## Prompt\n\n%s\n", p.Prompt)
+	}
+	if info.Description != "" {
+		fmt.Fprintf(&b, "\n## Description\n\n%s\n", info.Description)
+	}
+	if p.Advice != "" {
+		fmt.Fprintf(&b, "\n## Advice\n\n%s\n", p.Advice)
+	}
+	b.WriteString("\n## Accessibility\n\n")
+	b.WriteString(AccessibilityReport(p))
+	b.WriteString("\n## Files\n\nEach folder contains one export format; see `manifest.json` for checksums.\n")
+	return b.String()
+}
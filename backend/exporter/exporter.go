@@ -0,0 +1,191 @@
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/naming"
+	"ai-color-palette/theme"
+)
+
+// Palette 导出所需的配色数据
+type Palette struct {
+	Colors     []string `json:"colors"`
+	Names      []string `json:"names,omitempty"`
+	DarkColors []string `json:"dark_colors,omitempty"`
+	Advice     string   `json:"advice,omitempty"`
+	Prompt     string   `json:"prompt,omitempty"`
+	// Variant 终端/编辑器主题的明暗模式（dark 或 light）
+	Variant string `json:"variant,omitempty"`
+}
+
+// File 导出结果
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Exporter 将配色转换为某种文件格式
+type Exporter func(p *Palette) (*File, error)
+
+var exporters = map[string]Exporter{
+	"css":              exportCSS,
+	"scss":             exportSCSS,
+	"tokens":           exportTokens,
+	"ase":              exportASE,
+	"gpl":              exportGPL,
+	"png":              exportPNG,
+	"svg":              exportSVG,
+	"android":          exportAndroid,
+	"ios":              exportIOSColorset,
+	"flutter":          exportFlutter,
+	"swiftui":          exportSwiftUI,
+	"alacritty":        terminalExporter("paletteflow.toml", "application/toml", plain((*theme.Terminal).Alacritty)),
+	"kitty":            terminalExporter("paletteflow.conf", "text/plain; charset=utf-8", plain((*theme.Terminal).Kitty)),
+	"windows-terminal": terminalExporter("paletteflow-scheme.json", "application/json", (*theme.Terminal).WindowsTerminal),
+	"iterm2":           terminalExporter("PaletteFlow.itermcolors", "application/xml", plain((*theme.Terminal).ITerm2)),
+	"latex":            exportLaTeX,
+	"beamer":           exportBeamer,
+	"vscode":           terminalExporter("paletteflow-color-theme.json", "application/json", (*theme.Terminal).VSCode),
+}
+
+// Formats 返回所有支持的导出格式
+func Formats() []string {
+	formats := make([]string, 0, len(exporters))
+	for name := range exporters {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Export 按指定格式导出配色
+func Export(format string, p *Palette) (*File, error) {
+	exporter, ok := exporters[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return exporter(p)
+}
+
+func (p *Palette) validate() error {
+	if len(p.Colors) == 0 {
+		return fmt.Errorf("palette has no colors")
+	}
+	for i, color := range p.Colors {
+		normalized, ok := colorutil.NormalizeHex(color)
+		if !ok {
+			return fmt.Errorf("invalid color: %s", color)
+		}
+		p.Colors[i] = normalized
+	}
+	if len(p.DarkColors) > 0 && len(p.DarkColors) != len(p.Colors) {
+		return fmt.Errorf("dark_colors must contain %d colors", len(p.Colors))
+	}
+	for i, color := range p.DarkColors {
+		normalized, ok := colorutil.NormalizeHex(color)
+		if !ok {
+			return fmt.Errorf("invalid dark color: %s", color)
+		}
+		p.DarkColors[i] = normalized
+	}
+	return nil
+}
+
+// hasDark 是否提供了深色模式变体
+func (p *Palette) hasDark() bool {
+	return len(p.DarkColors) == len(p.Colors)
+}
+
+// nameWords 返回第 i 个颜色名称拆分出的单词；名称缺省或不含 ASCII 字母时使用最接近的英文色名
+func (p *Palette) nameWords(i int) []string {
+	if i < len(p.Names) {
+		if words := splitWords(p.Names[i]); len(words) > 0 {
+			return words
+		}
+	}
+	return splitWords(p.nearestName(i))
+}
+
+// nearestName 第 i 个颜色在英文色名表中最接近的名称
+func (p *Palette) nearestName(i int) string {
+	match, err := naming.Nearest(colorutil.MustParseHex(p.Colors[i]), naming.DatasetEnglish)
+	if err != nil {
+		return fmt.Sprintf("Color %d", i+1)
+	}
+	return match.Name
+}
+
+func splitWords(name string) []string {
+	var words []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+	for _, r := range name {
+		// 仅保留 ASCII 字母数字，保证在各平台均为合法标识符
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if unicode.IsUpper(r) && current.Len() > 0 {
+				flush()
+			}
+			current.WriteRune(r)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return words
+}
+
+// uniqueNames 按给定风格生成不重复的标识符
+func (p *Palette) uniqueNames(style func(words []string) string) []string {
+	names := make([]string, len(p.Colors))
+	seen := make(map[string]int)
+	for i := range p.Colors {
+		name := style(p.nameWords(i))
+		if unicode.IsDigit(rune(name[0])) {
+			name = style(append([]string{"color"}, p.nameWords(i)...))
+		}
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, seen[name])
+		}
+		names[i] = name
+	}
+	return names
+}
+
+func kebabCase(words []string) string {
+	return strings.Join(words, "-")
+}
+
+func snakeCase(words []string) string {
+	return strings.Join(words, "_")
+}
+
+func camelCase(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(word)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func pascalCase(words []string) string {
+	name := camelCase(words)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/theme"
+)
+
+// exportFlutter 生成 Dart 文件，包含 Color 常量与 Material ColorScheme
+func exportFlutter(p *Palette) (*File, error) {
+	names := p.uniqueNames(camelCase)
+
+	var b strings.Builder
+	b.WriteString("// Generated by PaletteFlow\n")
+	b.WriteString("import 'package:flutter/material.dart';\n\n")
+	b.WriteString("class PaletteColors {\n")
+	b.WriteString("  PaletteColors._();\n\n")
+	for i, color := range p.Colors {
+		fmt.Fprintf(&b, "  static const Color %s = %s;\n", names[i], dartColor(color))
+	}
+	if p.hasDark() {
+		b.WriteString("\n")
+		for i, color := range p.DarkColors {
+			fmt.Fprintf(&b, "  static const Color %sDark = %s;\n", names[i], dartColor(color))
+		}
+	}
+	b.WriteString("}\n\n")
+
+	writeDartColorScheme(&b, "paletteLightColorScheme", "Brightness.light", p.Colors, "#B3261E")
+	if p.hasDark() {
+		b.WriteString("\n")
+		writeDartColorScheme(&b, "paletteDarkColorScheme", "Brightness.dark", p.DarkColors, "#F2B8B5")
+	}
+
+	return &File{Name: "palette_colors.dart", ContentType: "text/plain; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+func writeDartColorScheme(b *strings.Builder, name, brightness string, colors []string, errorColor string) {
+	roles := theme.AssignRoles(colors, brightness == "Brightness.dark")
+	fmt.Fprintf(b, "const ColorScheme %s = ColorScheme(\n", name)
+	fmt.Fprintf(b, "  brightness: %s,\n", brightness)
+	for _, role := range []struct{ name, color string }{
+		{"primary", roles.Primary},
+		{"secondary", roles.Secondary},
+		{"tertiary", roles.Tertiary},
+		{"error", errorColor},
+		{"surface", roles.Surface},
+	} {
+		fmt.Fprintf(b, "  %s: %s,\n", role.name, dartColor(role.color))
+		onName := "on" + strings.ToUpper(role.name[:1]) + role.name[1:]
+		fmt.Fprintf(b, "  %s: %s,\n", onName, dartColor(onColor(role.color)))
+	}
+	b.WriteString(");\n")
+}
+
+func dartColor(hex string) string {
+	return "Color(0xFF" + strings.TrimPrefix(hex, "#") + ")"
+}
+
+func onColor(hex string) string {
+	return colorutil.BestTextColor(colorutil.MustParseHex(hex)).Hex()
+}
@@ -0,0 +1,89 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ai-color-palette/colorutil"
+)
+
+const assetCatalogName = "PaletteFlow.xcassets"
+
+type assetInfo struct {
+	Author  string `json:"author"`
+	Version int    `json:"version"`
+}
+
+type colorsetContents struct {
+	Colors []colorsetEntry `json:"colors"`
+	Info   assetInfo       `json:"info"`
+}
+
+type colorsetEntry struct {
+	Appearances []colorsetAppearance `json:"appearances,omitempty"`
+	Color       colorsetColor        `json:"color"`
+	Idiom       string               `json:"idiom"`
+}
+
+type colorsetAppearance struct {
+	Appearance string `json:"appearance"`
+	Value      string `json:"value"`
+}
+
+type colorsetColor struct {
+	ColorSpace string            `json:"color-space"`
+	Components map[string]string `json:"components"`
+}
+
+// exportIOSColorset 生成 Xcode 资源目录，每个颜色一个 .colorset 文件夹
+func exportIOSColorset(p *Palette) (*File, error) {
+	names := p.uniqueNames(pascalCase)
+	catalog, err := json.MarshalIndent(struct {
+		Info assetInfo `json:"info"`
+	}{Info: assetInfo{Author: "xcode", Version: 1}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal catalog contents: %w", err)
+	}
+
+	entries := []zipEntry{{Path: assetCatalogName + "/Contents.json", Data: catalog}}
+	for i, color := range p.Colors {
+		contents := colorsetContents{
+			Colors: []colorsetEntry{{Color: colorsetComponents(color), Idiom: "universal"}},
+			Info:   assetInfo{Author: "xcode", Version: 1},
+		}
+		if p.hasDark() {
+			contents.Colors = append(contents.Colors, colorsetEntry{
+				Appearances: []colorsetAppearance{{Appearance: "luminosity", Value: "dark"}},
+				Color:       colorsetComponents(p.DarkColors[i]),
+				Idiom:       "universal",
+			})
+		}
+		data, err := json.MarshalIndent(contents, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal colorset %s: %w", names[i], err)
+		}
+		entries = append(entries, zipEntry{
+			Path: fmt.Sprintf("%s/%s.colorset/Contents.json", assetCatalogName, names[i]),
+			Data: data,
+		})
+	}
+
+	data, err := zipEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Name: "PaletteFlow.xcassets.zip", ContentType: "application/zip", Data: data}, nil
+}
+
+func colorsetComponents(hex string) colorsetColor {
+	c := colorutil.MustParseHex(hex)
+	return colorsetColor{
+		ColorSpace: "srgb",
+		Components: map[string]string{
+			"alpha": "1.000",
+			"red":   fmt.Sprintf("0x%02X", c.R),
+			"green": fmt.Sprintf("0x%02X", c.G),
+			"blue":  fmt.Sprintf("0x%02X", c.B),
+		},
+	}
+}
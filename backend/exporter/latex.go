@@ -0,0 +1,96 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/theme"
+)
+
+// exportLaTeX 生成 xcolor 的 \definecolor 定义，同时给出 HTML 与 RGB 两种色彩模型
+func exportLaTeX(p *Palette) (*File, error) {
+	var b strings.Builder
+	writeLaTeXHeader(&b, p)
+	b.WriteString("% \\usepackage{xcolor}\n\n")
+	writeDefineColors(&b, p.latexNames(), p.Colors)
+	return &File{Name: "paletteflow-colors.tex", ContentType: "application/x-tex", Data: []byte(b.String())}, nil
+}
+
+// exportBeamer 生成 Beamer 颜色主题，使用 \usecolortheme{paletteflow} 加载
+func exportBeamer(p *Palette) (*File, error) {
+	names := p.latexNames()
+	roleName := func(color string) string {
+		for i, c := range p.Colors {
+			if c == color {
+				return names[i]
+			}
+		}
+		return ""
+	}
+	roles := theme.AssignRoles(p.Colors, false)
+	primary, secondary, accent, surface := roleName(roles.Primary), roleName(roles.Secondary), roleName(roles.Tertiary), roleName(roles.Surface)
+	onColor := func(color string) string {
+		if colorutil.BestTextColor(colorutil.MustParseHex(color)) == colorutil.White {
+			return "white"
+		}
+		return "black"
+	}
+
+	var b strings.Builder
+	writeLaTeXHeader(&b, p)
+	b.WriteString("% Usage: \\usecolortheme{paletteflow}\n")
+	b.WriteString("\\ProvidesPackage{beamercolorthemepaletteflow}\n\n")
+	b.WriteString("\\mode<presentation>\n\n")
+	writeDefineColors(&b, names, p.Colors)
+	b.WriteString("\n% Structure\n")
+	fmt.Fprintf(&b, "\\setbeamercolor{structure}{fg=%s}\n", primary)
+	fmt.Fprintf(&b, "\\setbeamercolor{palette primary}{fg=%s,bg=%s}\n", onColor(roles.Primary), primary)
+	fmt.Fprintf(&b, "\\setbeamercolor{palette secondary}{fg=%s,bg=%s}\n", onColor(roles.Secondary), secondary)
+	fmt.Fprintf(&b, "\\setbeamercolor{palette tertiary}{fg=%s,bg=%s}\n", onColor(roles.Tertiary), accent)
+	fmt.Fprintf(&b, "\\setbeamercolor{frametitle}{fg=%s}\n", primary)
+	fmt.Fprintf(&b, "\\setbeamercolor{title}{fg=%s}\n", primary)
+	b.WriteString("\n% Blocks\n")
+	fmt.Fprintf(&b, "\\setbeamercolor{block title}{fg=%s,bg=%s}\n", onColor(roles.Primary), primary)
+	fmt.Fprintf(&b, "\\setbeamercolor{block body}{bg=%s!40!white}\n", surface)
+	b.WriteString("\n% Alerted\n")
+	fmt.Fprintf(&b, "\\setbeamercolor{alerted text}{fg=%s}\n", accent)
+	fmt.Fprintf(&b, "\\setbeamercolor{block title alerted}{fg=%s,bg=%s}\n", onColor(roles.Tertiary), accent)
+	fmt.Fprintf(&b, "\\setbeamercolor{block body alerted}{bg=%s!15!white}\n", accent)
+	b.WriteString("\n% Example\n")
+	fmt.Fprintf(&b, "\\setbeamercolor{example text}{fg=%s}\n", secondary)
+	fmt.Fprintf(&b, "\\setbeamercolor{block title example}{fg=%s,bg=%s}\n", onColor(roles.Secondary), secondary)
+	fmt.Fprintf(&b, "\\setbeamercolor{block body example}{bg=%s!15!white}\n", secondary)
+	b.WriteString("\n\\mode<all>\n")
+
+	return &File{Name: "beamercolorthemepaletteflow.sty", ContentType: "application/x-tex", Data: []byte(b.String())}, nil
+}
+
+func writeLaTeXHeader(b *strings.Builder, p *Palette) {
+	b.WriteString("% Generated by PaletteFlow\n")
+	for _, text := range []string{p.Prompt, p.Advice} {
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(b, "%% %s\n", line)
+			}
+		}
+	}
+}
+
+// writeDefineColors 每个颜色输出 HTML 模型定义，以及带 RGB 后缀的 RGB 模型定义
+func writeDefineColors(b *strings.Builder, names, colors []string) {
+	for i, color := range colors {
+		c := colorutil.MustParseHex(color)
+		fmt.Fprintf(b, "\\definecolor{%s}{HTML}{%s}\n", names[i], strings.TrimPrefix(color, "#"))
+		fmt.Fprintf(b, "\\definecolor{%sRGB}{RGB}{%d,%d,%d}\n", names[i], c.R, c.G, c.B)
+	}
+}
+
+// latexNames 生成 xcolor 颜色名，仅含字母数字以避免与 LaTeX 特殊字符冲突
+func (p *Palette) latexNames() []string {
+	names := p.uniqueNames(pascalCase)
+	for i, name := range names {
+		names[i] = "Palette" + name
+	}
+	return names
+}
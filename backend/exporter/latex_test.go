@@ -0,0 +1,117 @@
+package exporter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+var latexTestPalette = Palette{
+	Colors: []string{"#264653", "#2A9D8F", "#E9C46A", "#F4A261", "#E76F51"},
+	Names:  []string{"Deep Sea", "Teal", "Saffron", "Sandy Brown", "Burnt Sienna"},
+	Prompt: "Coastal sunset",
+	Advice: "Use the deep sea blue for text.\nKeep the orange for highlights.",
+}
+
+func TestLaTeXGolden(t *testing.T) {
+	for _, format := range []string{"latex", "beamer"} {
+		t.Run(format, func(t *testing.T) {
+			p := latexTestPalette
+			file, err := Export(format, &p)
+			if err != nil {
+				t.Fatalf("Export(%q): %v", format, err)
+			}
+			checkLaTeXSyntax(t, string(file.Data))
+
+			golden := filepath.Join("testdata", format+".golden")
+			if *update {
+				if err := os.WriteFile(golden, file.Data, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create it): %v", err)
+			}
+			if string(file.Data) != string(want) {
+				t.Errorf("%s output differs from %s:\n%s", format, golden, file.Data)
+			}
+		})
+	}
+}
+
+var (
+	defineColorRe = regexp.MustCompile(`^\\definecolor\{([A-Za-z0-9]+)\}\{(HTML|RGB)\}\{([^{}]*)\}$`)
+	htmlValueRe   = regexp.MustCompile(`^[0-9A-F]{6}$`)
+	rgbValueRe    = regexp.MustCompile(`^\d{1,3},\d{1,3},\d{1,3}$`)
+	beamerColorRe = regexp.MustCompile(`^\\setbeamercolor\{[a-z ]+\}\{((?:fg|bg)=[^{},]+(?:,(?:fg|bg)=[^{},]+)?)\}$`)
+)
+
+// checkLaTeXSyntax 检查导出结果可被 LaTeX 解析：花括号配对、每条定义格式正确、
+// 引用的颜色都已定义、Beamer 的 \mode 成对出现
+func checkLaTeXSyntax(t *testing.T, src string) {
+	t.Helper()
+	defined := map[string]bool{"white": true, "black": true}
+	modes := 0
+	for n, line := range strings.Split(src, "\n") {
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+		depth := 0
+		for _, r := range line {
+			switch r {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+			if depth < 0 {
+				t.Fatalf("line %d: unbalanced braces: %s", n+1, line)
+			}
+		}
+		if depth != 0 {
+			t.Fatalf("line %d: unbalanced braces: %s", n+1, line)
+		}
+
+		switch {
+		case strings.HasPrefix(line, `\definecolor`):
+			m := defineColorRe.FindStringSubmatch(line)
+			if m == nil {
+				t.Fatalf("line %d: malformed \\definecolor: %s", n+1, line)
+			}
+			if (m[2] == "HTML" && !htmlValueRe.MatchString(m[3])) || (m[2] == "RGB" && !rgbValueRe.MatchString(m[3])) {
+				t.Fatalf("line %d: invalid %s value: %s", n+1, m[2], line)
+			}
+			defined[m[1]] = true
+		case strings.HasPrefix(line, `\setbeamercolor`):
+			m := beamerColorRe.FindStringSubmatch(line)
+			if m == nil {
+				t.Fatalf("line %d: malformed \\setbeamercolor: %s", n+1, line)
+			}
+			for _, option := range strings.Split(m[1], ",") {
+				// xcolor 混色表达式如 Name!40!white 中的每个颜色都必须已定义
+				for _, part := range strings.Split(option[3:], "!") {
+					if _, err := strconv.Atoi(part); err != nil && !defined[part] {
+						t.Fatalf("line %d: undefined color %q", n+1, part)
+					}
+				}
+			}
+		case line == `\mode<presentation>`:
+			modes++
+		case line == `\mode<all>`:
+			modes--
+		case strings.HasPrefix(line, `\ProvidesPackage{`):
+		default:
+			t.Fatalf("line %d: unexpected statement: %s", n+1, line)
+		}
+	}
+	if modes != 0 {
+		t.Fatalf("\\mode<presentation> is not closed by \\mode<all>")
+	}
+}
@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// AccessibilityReport 生成 Markdown 格式的可访问性报告：
+// 各颜色与黑/白文字的对比度、颜色两两对比度以及色盲模拟结果
+func AccessibilityReport(p *Palette) string {
+	rgbs := make([]colorutil.RGB, len(p.Colors))
+	for i, color := range p.Colors {
+		rgbs[i] = colorutil.MustParseHex(color)
+	}
+
+	var b strings.Builder
+	b.WriteString("| Color | Hex | vs White | vs Black | Best text |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for i, c := range rgbs {
+		white := colorutil.ContrastRatio(c, colorutil.White)
+		black := colorutil.ContrastRatio(c, colorutil.Black)
+		best := "white"
+		if black > white {
+			best = "black"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %.2f (%s) | %.2f (%s) | %s |\n", p.displayName(i), c.Hex(),
+			white, colorutil.ContrastLevel(white), black, colorutil.ContrastLevel(black), best)
+	}
+
+	b.WriteString("\n### Pairwise contrast\n\n|  |")
+	for _, c := range rgbs {
+		fmt.Fprintf(&b, " %s |", c.Hex())
+	}
+	b.WriteString("\n| --- |" + strings.Repeat(" --- |", len(rgbs)) + "\n")
+	for _, a := range rgbs {
+		fmt.Fprintf(&b, "| %s |", a.Hex())
+		for _, c := range rgbs {
+			fmt.Fprintf(&b, " %.2f |", colorutil.ContrastRatio(a, c))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n### Color vision deficiency simulation\n\n| Type |")
+	for _, c := range rgbs {
+		fmt.Fprintf(&b, " %s |", c.Hex())
+	}
+	b.WriteString("\n| --- |" + strings.Repeat(" --- |", len(rgbs)) + "\n")
+	for _, kind := range colorutil.CVDTypes {
+		fmt.Fprintf(&b, "| %s |", kind)
+		for _, c := range rgbs {
+			fmt.Fprintf(&b, " %s |", colorutil.SimulateCVD(c, kind).Hex())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"unicode/utf16"
+
+	"ai-color-palette/colorutil"
+)
+
+const swatchSize = 200
+
+// exportASE 生成 Adobe Swatch Exchange 文件
+func exportASE(p *Palette) (*File, error) {
+	var blocks bytes.Buffer
+	count := uint32(0)
+	writeBlock := func(blockType uint16, body []byte) {
+		binary.Write(&blocks, binary.BigEndian, blockType)
+		binary.Write(&blocks, binary.BigEndian, uint32(len(body)))
+		blocks.Write(body)
+		count++
+	}
+
+	writeBlock(0xC001, aseName("PaletteFlow"))
+	for i, color := range p.Colors {
+		c := colorutil.MustParseHex(color)
+		r, g, b := c.Floats()
+		var body bytes.Buffer
+		body.Write(aseName(p.displayName(i)))
+		body.WriteString("RGB ")
+		for _, v := range []float64{r, g, b} {
+			binary.Write(&body, binary.BigEndian, float32(v))
+		}
+		// 0 = global, 1 = spot, 2 = normal
+		binary.Write(&body, binary.BigEndian, uint16(2))
+		writeBlock(0x0001, body.Bytes())
+	}
+	writeBlock(0xC002, nil)
+
+	var buf bytes.Buffer
+	buf.WriteString("ASEF")
+	binary.Write(&buf, binary.BigEndian, uint16(1))
+	binary.Write(&buf, binary.BigEndian, uint16(0))
+	binary.Write(&buf, binary.BigEndian, count)
+	buf.Write(blocks.Bytes())
+	return &File{Name: "palette.ase", ContentType: "application/octet-stream", Data: buf.Bytes()}, nil
+}
+
+func aseName(name string) []byte {
+	units := append(utf16.Encode([]rune(name)), 0)
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint16(len(units)))
+	binary.Write(&buf, binary.BigEndian, units)
+	return buf.Bytes()
+}
+
+// exportGPL 生成 GIMP/Inkscape 调色板
+func exportGPL(p *Palette) (*File, error) {
+	var b strings.Builder
+	b.WriteString("GIMP Palette\nName: PaletteFlow\n")
+	fmt.Fprintf(&b, "Columns: %d\n#\n", len(p.Colors))
+	for i, color := range p.Colors {
+		c := colorutil.MustParseHex(color)
+		fmt.Fprintf(&b, "%3d %3d %3d\t%s\n", c.R, c.G, c.B, p.displayName(i))
+	}
+	return &File{Name: "palette.gpl", ContentType: "text/plain; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+// exportPNG 生成横向排列的色块图片
+func exportPNG(p *Palette) (*File, error) {
+	img := image.NewRGBA(image.Rect(0, 0, swatchSize*len(p.Colors), swatchSize))
+	for i, hex := range p.Colors {
+		c := colorutil.MustParseHex(hex)
+		rect := image.Rect(i*swatchSize, 0, (i+1)*swatchSize, swatchSize)
+		draw.Draw(img, rect, &image.Uniform{C: color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}}, image.Point{}, draw.Src)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return &File{Name: "palette.png", ContentType: "image/png", Data: buf.Bytes()}, nil
+}
+
+// exportSVG 生成带颜色标注的色块矢量图
+func exportSVG(p *Palette) (*File, error) {
+	width := swatchSize * len(p.Colors)
+	height := swatchSize + 60
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	b.WriteString("  <rect width=\"100%\" height=\"100%\" fill=\"#FFFFFF\"/>\n")
+	for i, color := range p.Colors {
+		x := i * swatchSize
+		fmt.Fprintf(&b, "  <rect x=\"%d\" y=\"0\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", x, swatchSize, swatchSize, color)
+		fmt.Fprintf(&b, "  <text x=\"%d\" y=\"%d\" font-family=\"sans-serif\" font-size=\"16\" text-anchor=\"middle\" fill=\"#333333\">%s</text>\n",
+			x+swatchSize/2, swatchSize+24, color)
+		fmt.Fprintf(&b, "  <text x=\"%d\" y=\"%d\" font-family=\"sans-serif\" font-size=\"12\" text-anchor=\"middle\" fill=\"#777777\">%s</text>\n",
+			x+swatchSize/2, swatchSize+44, xmlEscape(p.displayName(i)))
+	}
+	b.WriteString("</svg>\n")
+	return &File{Name: "palette.svg", ContentType: "image/svg+xml", Data: []byte(b.String())}, nil
+}
+
+// displayName 返回用户可读的颜色名称，缺省为最接近的英文色名
+func (p *Palette) displayName(i int) string {
+	if i < len(p.Names) && strings.TrimSpace(p.Names[i]) != "" {
+		return strings.TrimSpace(p.Names[i])
+	}
+	return p.nearestName(i)
+}
+
+func xmlEscape(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
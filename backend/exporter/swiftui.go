@@ -0,0 +1,51 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// exportSwiftUI 生成 SwiftUI Color 扩展，有深色变体时按系统外观动态切换
+func exportSwiftUI(p *Palette) (*File, error) {
+	names := p.uniqueNames(pascalCase)
+
+	var b strings.Builder
+	b.WriteString("// Generated by PaletteFlow\n")
+	b.WriteString("import SwiftUI\n")
+	if p.hasDark() {
+		b.WriteString("#if canImport(UIKit)\nimport UIKit\n#endif\n")
+	}
+	b.WriteString("\nextension Color {\n")
+	for i, color := range p.Colors {
+		if p.hasDark() {
+			fmt.Fprintf(&b, "    static let palette%s = Color(light: %s, dark: %s)\n",
+				names[i], swiftColor(color), swiftColor(p.DarkColors[i]))
+			continue
+		}
+		fmt.Fprintf(&b, "    static let palette%s = %s\n", names[i], swiftColor(color))
+	}
+
+	if p.hasDark() {
+		b.WriteString(`
+    init(light: Color, dark: Color) {
+        #if canImport(UIKit)
+        self.init(UIColor { traits in
+            traits.userInterfaceStyle == .dark ? UIColor(dark) : UIColor(light)
+        })
+        #else
+        self = light
+        #endif
+    }
+`)
+	}
+	b.WriteString("}\n")
+
+	return &File{Name: "Color+Palette.swift", ContentType: "text/plain; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+func swiftColor(hex string) string {
+	r, g, b := colorutil.MustParseHex(hex).Floats()
+	return fmt.Sprintf("Color(.sRGB, red: %.3f, green: %.3f, blue: %.3f, opacity: 1)", r, g, b)
+}
@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"fmt"
+
+	"ai-color-palette/theme"
+)
+
+// terminalExporter 将配色映射为终端/编辑器主题后按指定格式输出
+func terminalExporter(name, contentType string, render func(t *theme.Terminal) ([]byte, error)) Exporter {
+	return func(p *Palette) (*File, error) {
+		t, err := theme.GenerateTerminal(p.Colors, theme.TerminalOptions{
+			Description: p.Advice,
+			Variant:     p.Variant,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("generate terminal theme: %w", err)
+		}
+		data, err := render(t)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", name, err)
+		}
+		return &File{Name: name, ContentType: contentType, Data: data}, nil
+	}
+}
+
+func plain(render func(t *theme.Terminal) []byte) func(t *theme.Terminal) ([]byte, error) {
+	return func(t *theme.Terminal) ([]byte, error) {
+		return render(t), nil
+	}
+}
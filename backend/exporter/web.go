@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// exportCSS 生成 CSS 自定义属性
+func exportCSS(p *Palette) (*File, error) {
+	names := p.uniqueNames(kebabCase)
+	var b strings.Builder
+	b.WriteString("/* Generated by PaletteFlow */\n:root {\n")
+	for i, color := range p.Colors {
+		fmt.Fprintf(&b, "  --palette-%s: %s;\n", names[i], color)
+	}
+	b.WriteString("}\n")
+	if p.hasDark() {
+		b.WriteString("\n@media (prefers-color-scheme: dark) {\n  :root {\n")
+		for i, color := range p.DarkColors {
+			fmt.Fprintf(&b, "    --palette-%s: %s;\n", names[i], color)
+		}
+		b.WriteString("  }\n}\n")
+	}
+	return &File{Name: "palette.css", ContentType: "text/css; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+// exportSCSS 生成 SCSS 变量与颜色映射
+func exportSCSS(p *Palette) (*File, error) {
+	names := p.uniqueNames(kebabCase)
+	var b strings.Builder
+	b.WriteString("// Generated by PaletteFlow\n")
+	for i, color := range p.Colors {
+		fmt.Fprintf(&b, "$palette-%s: %s;\n", names[i], color)
+	}
+	b.WriteString("\n$palette: (\n")
+	for i := range p.Colors {
+		fmt.Fprintf(&b, "  \"%s\": $palette-%s,\n", names[i], names[i])
+	}
+	b.WriteString(");\n")
+	return &File{Name: "_palette.scss", ContentType: "text/x-scss; charset=utf-8", Data: []byte(b.String())}, nil
+}
+
+type designToken struct {
+	Value       string `json:"$value"`
+	Description string `json:"$description,omitempty"`
+}
+
+// exportTokens 生成 W3C DTCG 设计令牌
+func exportTokens(p *Palette) (*File, error) {
+	names := p.uniqueNames(kebabCase)
+	group := map[string]interface{}{"$type": "color"}
+	if p.Advice != "" {
+		group["$description"] = p.Advice
+	}
+	for i, color := range p.Colors {
+		token := designToken{Value: color}
+		if i < len(p.Names) {
+			token.Description = p.Names[i]
+		}
+		group[names[i]] = token
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"palette": group}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal tokens: %w", err)
+	}
+	return &File{Name: "palette.tokens.json", ContentType: "application/json", Data: data}, nil
+}
@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+)
+
+type zipEntry struct {
+	Path string
+	Data []byte
+}
+
+// zipEntries 将多个文件打包为 zip
+func zipEntries(entries []zipEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := zw.Create(entry.Path)
+		if err != nil {
+			return nil, fmt.Errorf("create zip entry %s: %w", entry.Path, err)
+		}
+		if _, err := w.Write(entry.Data); err != nil {
+			return nil, fmt.Errorf("write zip entry %s: %w", entry.Path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close zip: %w", err)
+	}
+	return buf.Bytes(), nil
+}
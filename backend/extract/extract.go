@@ -0,0 +1,284 @@
+package extract
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	KindCSS  = "css"
+	KindSVG  = "svg"
+	KindHTML = "html"
+
+	// DefaultThreshold 合并相近颜色的 CIEDE2000 阈值
+	DefaultThreshold = 3.0
+)
+
+var (
+	commentRegex     = regexp.MustCompile(`(?s)/\*.*?\*/|<!--.*?-->`)
+	styleBlockRegex  = regexp.MustCompile(`(?is)<style[^>]*>(.*?)</style>`)
+	styleAttrRegex   = regexp.MustCompile(`(?i)\sstyle\s*=\s*("([^"]*)"|'([^']*)')`)
+	presentAttrRegex = regexp.MustCompile(`(?i)\s(fill|stroke|stop-color|flood-color|lighting-color|color|bgcolor|background)\s*=\s*("([^"]*)"|'([^']*)')`)
+	declarationRegex = regexp.MustCompile(`([\w-]+)\s*:\s*([^;{}]+)`)
+	literalRegex     = regexp.MustCompile(`(?i)#[0-9a-f]{3,8}\b|(?:rgba?|hsla?)\([^)]*\)|var\(\s*--[\w-]+[^)]*\)|\b[a-z]{3,20}\b`)
+	urlRegex         = regexp.MustCompile(`(?i)url\([^)]*\)`)
+	varRefRegex      = regexp.MustCompile(`var\(\s*(--[\w-]+)`)
+)
+
+// Options 提取参数
+type Options struct {
+	Count     int     // 返回的精简配色数量
+	Threshold float64 // 相近颜色合并阈值（ΔE2000）
+}
+
+// Member 合并前的一种颜色及其出现次数
+type Member struct {
+	Color string `json:"color"`
+	Count int    `json:"count"`
+}
+
+// Cluster 合并相近颜色后的一组颜色
+type Cluster struct {
+	Color      string         `json:"color"`
+	Count      int            `json:"count"`
+	Share      float64        `json:"share"`
+	Members    []Member       `json:"members"`
+	Properties map[string]int `json:"properties"`
+	Variables  []string       `json:"variables,omitempty"`
+	Literals   []string       `json:"literals"`
+}
+
+// Report 颜色使用报告
+type Report struct {
+	Kind         string            `json:"kind"`
+	Palette      []string          `json:"palette"`
+	Occurrences  int               `json:"occurrences"`
+	UniqueColors int               `json:"unique_colors"`
+	Clusters     []Cluster         `json:"clusters"`
+	Variables    map[string]string `json:"variables"`
+}
+
+type occurrence struct {
+	color    string
+	property string
+	literal  string
+	variable string
+}
+
+// DetectKind 根据扩展名与内容判断文档类型
+func DetectKind(filename string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".svg":
+		return KindSVG
+	case ".html", ".htm", ".vue", ".xhtml":
+		return KindHTML
+	case ".css", ".scss", ".less":
+		return KindCSS
+	}
+	head := strings.ToLower(string(data[:min(len(data), 512)]))
+	switch {
+	case strings.Contains(head, "<svg"):
+		return KindSVG
+	case strings.Contains(head, "<html") || strings.Contains(head, "<!doctype"):
+		return KindHTML
+	}
+	return KindCSS
+}
+
+// Analyze 收集文档中的所有颜色字面量，合并相近色并按使用频率排序
+func Analyze(kind string, data []byte, opts Options) (*Report, error) {
+	if opts.Count <= 0 {
+		opts.Count = 5
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	text := commentRegex.ReplaceAllString(string(data), "")
+
+	var declarations [][2]string
+	switch kind {
+	case KindCSS:
+		declarations = cssDeclarations(text)
+	case KindSVG, KindHTML:
+		for _, m := range styleBlockRegex.FindAllStringSubmatch(text, -1) {
+			declarations = append(declarations, cssDeclarations(m[1])...)
+		}
+		for _, m := range styleAttrRegex.FindAllStringSubmatch(text, -1) {
+			declarations = append(declarations, cssDeclarations(m[2]+m[3])...)
+		}
+		for _, m := range presentAttrRegex.FindAllStringSubmatch(text, -1) {
+			declarations = append(declarations, [2]string{strings.ToLower(m[1]), m[3] + m[4]})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported document kind: %s", kind)
+	}
+
+	// 先解析自定义属性定义，var() 引用按其解析结果计数
+	variables := make(map[string]string)
+	for _, d := range declarations {
+		if strings.HasPrefix(d[0], "--") {
+			if c, ok := firstColor(d[1], variables); ok {
+				variables[d[0]] = c
+			}
+		}
+	}
+
+	var occurrences []occurrence
+	for _, d := range declarations {
+		for _, literal := range colorLiterals(d[1]) {
+			occ := occurrence{property: d[0], literal: literal}
+			if m := varRefRegex.FindStringSubmatch(literal); m != nil {
+				color, ok := variables[m[1]]
+				if !ok {
+					continue
+				}
+				occ.color, occ.variable = color, m[1]
+			} else if c, ok := colorutil.ParseCSSColor(literal); ok {
+				occ.color = c.Hex()
+				if strings.HasPrefix(d[0], "--") {
+					occ.variable = d[0]
+				}
+			} else {
+				continue
+			}
+			occurrences = append(occurrences, occ)
+		}
+	}
+	if len(occurrences) == 0 {
+		return nil, fmt.Errorf("no colors found")
+	}
+
+	report := &Report{Kind: kind, Occurrences: len(occurrences), Variables: variables}
+	report.Clusters, report.UniqueColors = cluster(occurrences, opts.Threshold)
+	for i := 0; i < len(report.Clusters) && i < opts.Count; i++ {
+		report.Palette = append(report.Palette, report.Clusters[i].Color)
+	}
+	return report, nil
+}
+
+// colorLiterals 找出声明值中可能表示颜色的片段。颜色名只在独立成词时计入，
+// 即前后为值的开头结尾、空白、逗号或括号，url() 中的文件名与 image-red 这类标识符中的单词不算
+func colorLiterals(value string) []string {
+	value = urlRegex.ReplaceAllString(value, "")
+	var out []string
+	for _, loc := range literalRegex.FindAllStringIndex(value, -1) {
+		literal := value[loc[0]:loc[1]]
+		if isWord(literal) {
+			before := loc[0] == 0 || strings.ContainsRune(" \t\n,(", rune(value[loc[0]-1]))
+			after := loc[1] == len(value) || strings.ContainsRune(" \t\n,)!", rune(value[loc[1]]))
+			if !before || !after {
+				continue
+			}
+		}
+		out = append(out, literal)
+	}
+	return out
+}
+
+func isWord(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func cssDeclarations(css string) [][2]string {
+	var out [][2]string
+	for _, m := range declarationRegex.FindAllStringSubmatch(css, -1) {
+		out = append(out, [2]string{strings.ToLower(m[1]), m[2]})
+	}
+	return out
+}
+
+func firstColor(value string, variables map[string]string) (string, bool) {
+	for _, literal := range colorLiterals(value) {
+		if m := varRefRegex.FindStringSubmatch(literal); m != nil {
+			if c, ok := variables[m[1]]; ok {
+				return c, true
+			}
+			continue
+		}
+		if c, ok := colorutil.ParseCSSColor(literal); ok {
+			return c.Hex(), true
+		}
+	}
+	return "", false
+}
+
+// cluster 按出现次数从高到低贪心合并 ΔE2000 小于阈值的颜色，代表色取组内最常用的颜色
+func cluster(occurrences []occurrence, threshold float64) ([]Cluster, int) {
+	counts := make(map[string]int)
+	for _, occ := range occurrences {
+		counts[occ.color]++
+	}
+	colors := make([]string, 0, len(counts))
+	for color := range counts {
+		colors = append(colors, color)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if counts[colors[i]] != counts[colors[j]] {
+			return counts[colors[i]] > counts[colors[j]]
+		}
+		return colors[i] < colors[j]
+	})
+
+	var clusters []Cluster
+	var centers []colorutil.Lab
+	owner := make(map[string]int)
+	for _, color := range colors {
+		lab := colorutil.ToLab(colorutil.MustParseHex(color))
+		target := -1
+		for i, center := range centers {
+			if colorutil.DeltaE2000(lab, center) < threshold {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			target = len(clusters)
+			clusters = append(clusters, Cluster{Color: color, Properties: make(map[string]int)})
+			centers = append(centers, lab)
+		}
+		owner[color] = target
+		clusters[target].Members = append(clusters[target].Members, Member{Color: color, Count: counts[color]})
+		clusters[target].Count += counts[color]
+	}
+
+	literals := make([]map[string]bool, len(clusters))
+	variables := make([]map[string]bool, len(clusters))
+	for i := range clusters {
+		literals[i], variables[i] = make(map[string]bool), make(map[string]bool)
+	}
+	for _, occ := range occurrences {
+		i := owner[occ.color]
+		clusters[i].Properties[occ.property]++
+		literals[i][occ.literal] = true
+		if occ.variable != "" {
+			variables[i][occ.variable] = true
+		}
+	}
+	for i := range clusters {
+		clusters[i].Share = math.Round(float64(clusters[i].Count)/float64(len(occurrences))*10000) / 10000
+		clusters[i].Literals = sortedKeys(literals[i])
+		clusters[i].Variables = sortedKeys(variables[i])
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].Count > clusters[j].Count })
+	return clusters, len(colors)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,274 @@
+// Package gradient 由配色生成多段渐变，支持多种插值空间，输出 CSS、SVG 与采样色
+package gradient
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// 插值空间
+const (
+	SpaceSRGB  = "srgb"
+	SpaceOKLab = "oklab"
+	SpaceOKLCH = "oklch"
+)
+
+// 色相插值方向，与 CSS Color 4 的 hue-interpolation-method 同名
+const (
+	HueShorter    = "shorter"
+	HueLonger     = "longer"
+	HueIncreasing = "increasing"
+	HueDecreasing = "decreasing"
+)
+
+const (
+	defaultSamples = 7
+	maxSamples     = 256
+	// deadZoneRatio 段中点彩度低于两端平均彩度的该比例时视为灰色死区
+	deadZoneRatio = 0.6
+	// achromatic 彩度低于此值时色相无意义，插值时沿用另一端的色相
+	achromatic = 0.02
+)
+
+// Options 渐变参数
+type Options struct {
+	Space string
+	Hue   string
+	// Positions 各颜色在渐变中的位置（0-1），为空时均匀分布
+	Positions []float64
+	// Midpoints 为 true 时在会穿过灰色区域的段中插入保持彩度的中点
+	Midpoints bool
+	Samples   int
+	// Angle 线性渐变角度，与 CSS 一致（0 为向上，90 为向右）
+	Angle float64
+}
+
+// Stop 渐变色标
+type Stop struct {
+	Color    string  `json:"color"`
+	Position float64 `json:"position"`
+	// Inserted 为 true 表示该色标是为避免灰色死区自动插入的中点
+	Inserted bool `json:"inserted,omitempty"`
+}
+
+// Gradient 生成结果；CSS 与 SVG 使用插值后的密集色标，在不支持 OKLCH 插值的浏览器中效果一致
+type Gradient struct {
+	Space     string   `json:"space"`
+	Hue       string   `json:"hue,omitempty"`
+	Stops     []Stop   `json:"stops"`
+	Samples   []string `json:"samples"`
+	CSSLinear string   `json:"css_linear"`
+	CSSConic  string   `json:"css_conic"`
+	SVG       string   `json:"svg"`
+}
+
+// Build 按给定空间在颜色之间插值生成渐变
+func Build(colors []string, opts Options) (*Gradient, error) {
+	if len(colors) < 2 {
+		return nil, fmt.Errorf("gradient needs at least 2 colors")
+	}
+	opts.Space = strings.ToLower(strings.TrimSpace(opts.Space))
+	if opts.Space == "" {
+		opts.Space = SpaceOKLab
+	}
+	if opts.Space != SpaceSRGB && opts.Space != SpaceOKLab && opts.Space != SpaceOKLCH {
+		return nil, fmt.Errorf("unsupported interpolation space: %s", opts.Space)
+	}
+	opts.Hue = strings.ToLower(strings.TrimSpace(opts.Hue))
+	if opts.Space != SpaceOKLCH {
+		opts.Hue = ""
+	} else if opts.Hue == "" {
+		opts.Hue = HueShorter
+	} else if opts.Hue != HueShorter && opts.Hue != HueLonger && opts.Hue != HueIncreasing && opts.Hue != HueDecreasing {
+		return nil, fmt.Errorf("unsupported hue direction: %s", opts.Hue)
+	}
+	if opts.Samples == 0 {
+		opts.Samples = defaultSamples
+	}
+	if opts.Samples < 2 || opts.Samples > maxSamples {
+		return nil, fmt.Errorf("samples must be between 2 and %d", maxSamples)
+	}
+	if len(opts.Positions) != 0 && len(opts.Positions) != len(colors) {
+		return nil, fmt.Errorf("positions must match colors (%d != %d)", len(opts.Positions), len(colors))
+	}
+
+	stops := make([]Stop, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		pos := float64(i) / float64(len(colors)-1)
+		if len(opts.Positions) != 0 {
+			pos = opts.Positions[i]
+			if pos < 0 || pos > 1 {
+				return nil, fmt.Errorf("position %v out of range [0, 1]", pos)
+			}
+		}
+		stops[i] = Stop{Color: c.Hex(), Position: pos}
+	}
+	sort.SliceStable(stops, func(i, j int) bool { return stops[i].Position < stops[j].Position })
+	if opts.Midpoints {
+		stops = insertMidpoints(stops, opts)
+	}
+
+	g := &Gradient{Space: opts.Space, Hue: opts.Hue, Stops: stops}
+	for i := 0; i < opts.Samples; i++ {
+		g.Samples = append(g.Samples, g.At(float64(i)/float64(opts.Samples-1)).Hex())
+	}
+
+	// 输出用的密集色标：每段至少细分 8 次，使 sRGB 渲染接近目标空间的插值
+	dense := denseStops(g, opts.Samples)
+	g.CSSLinear = fmt.Sprintf("linear-gradient(%sdeg, %s)", formatNumber(opts.Angle), cssStops(dense, "%"))
+	g.CSSConic = fmt.Sprintf("conic-gradient(from %sdeg, %s)", formatNumber(opts.Angle), cssStops(dense, "%"))
+	g.SVG = svgDefs(dense, opts.Angle)
+	return g, nil
+}
+
+// At 返回渐变在 t（0-1）处的颜色
+func (g *Gradient) At(t float64) colorutil.RGB {
+	stops := g.Stops
+	if t <= stops[0].Position {
+		return colorutil.MustParseHex(stops[0].Color)
+	}
+	for i := 1; i < len(stops); i++ {
+		if t > stops[i].Position {
+			continue
+		}
+		span := stops[i].Position - stops[i-1].Position
+		local := 1.0
+		if span > 0 {
+			local = (t - stops[i-1].Position) / span
+		}
+		return interpolate(colorutil.MustParseHex(stops[i-1].Color), colorutil.MustParseHex(stops[i].Color), local, g.Space, g.Hue)
+	}
+	return colorutil.MustParseHex(stops[len(stops)-1].Color)
+}
+
+func interpolate(a, b colorutil.RGB, t float64, space, hue string) colorutil.RGB {
+	switch space {
+	case SpaceSRGB:
+		lerp := func(x, y uint8) float64 { return (float64(x) + (float64(y)-float64(x))*t) / 255 }
+		return colorutil.RGB{R: colorutil.ToByte(lerp(a.R, b.R)), G: colorutil.ToByte(lerp(a.G, b.G)), B: colorutil.ToByte(lerp(a.B, b.B))}
+	case SpaceOKLCH:
+		la, lb := colorutil.ToOKLCH(a), colorutil.ToOKLCH(b)
+		if la.C < achromatic {
+			la.H = lb.H
+		}
+		if lb.C < achromatic {
+			lb.H = la.H
+		}
+		return colorutil.OKLCH{
+			L: la.L + (lb.L-la.L)*t,
+			C: la.C + (lb.C-la.C)*t,
+			H: colorutil.NormalizeHue(la.H + hueDelta(la.H, lb.H, hue)*t),
+		}.RGB()
+	default:
+		return colorutil.Mix(a, b, t)
+	}
+}
+
+// hueDelta 按方向计算从 a 到 b 的色相增量
+func hueDelta(a, b float64, direction string) float64 {
+	d := math.Mod(b-a+360, 360) // [0, 360) 的正向增量
+	switch direction {
+	case HueLonger:
+		if d != 0 && d < 180 {
+			return d - 360
+		}
+		if d == 0 {
+			return 360
+		}
+		return d
+	case HueIncreasing:
+		return d
+	case HueDecreasing:
+		if d == 0 {
+			return 0
+		}
+		return d - 360
+	default:
+		if d > 180 {
+			return d - 360
+		}
+		return d
+	}
+}
+
+// insertMidpoints 在段中点彩度明显低于两端时插入一个保持彩度的中点，
+// 中点明度取两端平均，色相沿插值方向取一半，避免互补色之间的灰色死区
+func insertMidpoints(stops []Stop, opts Options) []Stop {
+	out := []Stop{stops[0]}
+	for i := 1; i < len(stops); i++ {
+		a, b := colorutil.MustParseHex(stops[i-1].Color), colorutil.MustParseHex(stops[i].Color)
+		la, lb := colorutil.ToOKLCH(a), colorutil.ToOKLCH(b)
+		avgC := (la.C + lb.C) / 2
+		mid := colorutil.ToOKLCH(interpolate(a, b, 0.5, opts.Space, opts.Hue))
+		if la.C >= achromatic && lb.C >= achromatic && mid.C < avgC*deadZoneRatio {
+			direction := opts.Hue
+			if direction == "" {
+				direction = HueShorter
+			}
+			m := colorutil.OKLCH{
+				L: (la.L + lb.L) / 2,
+				C: avgC,
+				H: colorutil.NormalizeHue(la.H + hueDelta(la.H, lb.H, direction)/2),
+			}.RGB()
+			out = append(out, Stop{
+				Color:    m.Hex(),
+				Position: (stops[i-1].Position + stops[i].Position) / 2,
+				Inserted: true,
+			})
+		}
+		out = append(out, stops[i])
+	}
+	return out
+}
+
+func denseStops(g *Gradient, samples int) []Stop {
+	n := (len(g.Stops)-1)*8 + 1
+	if samples > n {
+		n = samples
+	}
+	stops := make([]Stop, n)
+	for i := range stops {
+		t := float64(i) / float64(n-1)
+		stops[i] = Stop{Color: g.At(t).Hex(), Position: t}
+	}
+	return stops
+}
+
+func cssStops(stops []Stop, unit string) string {
+	parts := make([]string, len(stops))
+	for i, s := range stops {
+		parts[i] = fmt.Sprintf("%s %s%s", s.Color, formatNumber(s.Position*100), unit)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// svgDefs 生成可直接嵌入 SVG 的线性渐变定义，角度换算为起止坐标
+func svgDefs(stops []Stop, angle float64) string {
+	rad := (angle - 90) * math.Pi / 180
+	dx, dy := math.Cos(rad)/2, math.Sin(rad)/2
+	var b strings.Builder
+	fmt.Fprintf(&b, `<defs><linearGradient id="paletteflow-gradient" x1="%s" y1="%s" x2="%s" y2="%s">`,
+		formatNumber(0.5-dx), formatNumber(0.5-dy), formatNumber(0.5+dx), formatNumber(0.5+dy))
+	for _, s := range stops {
+		fmt.Fprintf(&b, `<stop offset="%s%%" stop-color="%s"/>`, formatNumber(s.Position*100), s.Color)
+	}
+	b.WriteString(`</linearGradient></defs>`)
+	return b.String()
+}
+
+func formatNumber(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
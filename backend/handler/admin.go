@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/billing"
+	"ai-color-palette/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth 要求请求携带 Authorization: Bearer <ADMIN_TOKEN>，未配置 ADMIN_TOKEN 时拒绝所有请求
+func AdminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := config.AppConfig.AdminToken
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin endpoints are disabled, set ADMIN_TOKEN to enable them"})
+			return
+		}
+		given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UsageHandler 返回某月的 token 用量与费用，按操作、模型与 API Key 汇总；month 为空时为本月
+func UsageHandler(c *gin.Context) {
+	month := c.Query("month")
+	if month != "" {
+		if _, err := time.Parse("2006-01", month); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "month must be in YYYY-MM format"})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, billing.MonthlyReport(month))
+}
+
+// CacheHandler 返回配色缓存的项数与命中次数
+func CacheHandler(c *gin.Context) {
+	stats := ai.CacheStats()
+	if stats == nil {
+		c.JSON(http.StatusOK, gin.H{"enabled": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"enabled": true, "stats": stats})
+}
+
+// MetricsHandler 以 Prometheus 文本格式输出本月用量、预算状态与缓存命中情况
+func MetricsHandler(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	billing.WriteMetrics(c.Writer)
+	if stats := ai.CacheStats(); stats != nil {
+		fmt.Fprintf(c.Writer, "# HELP paletteflow_ai_cache_entries Palettes held in the in-memory cache.\n# TYPE paletteflow_ai_cache_entries gauge\n")
+		fmt.Fprintf(c.Writer, "paletteflow_ai_cache_entries %d\n", stats.Entries)
+		fmt.Fprintf(c.Writer, "# HELP paletteflow_ai_cache_lookups_total Cache lookups since start.\n# TYPE paletteflow_ai_cache_lookups_total counter\n")
+		fmt.Fprintf(c.Writer, "paletteflow_ai_cache_lookups_total{result=\"hit\"} %d\n", stats.Hits)
+		fmt.Fprintf(c.Writer, "paletteflow_ai_cache_lookups_total{result=\"miss\"} %d\n", stats.Misses)
+	}
+}
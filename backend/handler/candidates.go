@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"log"
+	"sort"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/colorutil"
+	"ai-color-palette/dataviz"
+	"ai-color-palette/score"
+)
+
+// minCandidateDistance 两个候选配色的平均最近 ΔE2000 低于此值时视为重复，只保留评分较高者
+const minCandidateDistance = 6.0
+
+type rankedCandidate struct {
+	result *ai.PaletteResult
+	rgbs   []colorutil.RGB
+	score  float64
+	// pass 可视化用途校验是否通过，非可视化配色恒为 true
+	pass bool
+}
+
+// generateCandidates 并行生成多个候选配色，去掉彼此过于接近的结果后按评分从高到低返回
+// （可视化用途下通过校验的优先）；
+// 全部失败时退回单个降级配色
+func generateCandidates(req *ColorPaletteRequest, image *ai.Image) ColorPaletteResponse {
+	results, err := ai.GenerateCandidates(req.Prompt, req.Candidates, ai.Options{Image: image, Purpose: req.Purpose, Agentic: req.Agentic})
+	if err != nil {
+		results = []*ai.PaletteResult{fallbackPalette(req, err)}
+	}
+
+	ranked := make([]rankedCandidate, 0, len(results))
+	for _, result := range results {
+		candidate := rankedCandidate{result: result, rgbs: make([]colorutil.RGB, len(result.Colors)), pass: true}
+		for i, color := range result.Colors {
+			candidate.rgbs[i] = colorutil.MustParseHex(color)
+		}
+		if s, err := score.Evaluate(result.Colors); err == nil {
+			candidate.score = s.Overall
+		}
+		if req.Purpose != "" {
+			report, err := dataviz.Validate(req.Purpose, result.Colors)
+			candidate.pass = err == nil && report.Pass
+		}
+		ranked = append(ranked, candidate)
+	}
+	// 通过用途校验的排在前面，其次按评分
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].pass != ranked[j].pass {
+			return ranked[i].pass
+		}
+		return ranked[i].score > ranked[j].score
+	})
+
+	// 按评分从高到低贪心保留，与已保留候选过于接近的丢弃
+	var kept []rankedCandidate
+	for _, candidate := range ranked {
+		duplicate := false
+		for _, k := range kept {
+			if colorutil.PaletteDistance(candidate.rgbs, k.rgbs) < minCandidateDistance {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			kept = append(kept, candidate)
+		}
+	}
+	log.Printf("[INFO] Generated %d candidates, %d kept after de-duplication", len(results), len(kept))
+
+	candidates := make([]ColorPaletteResponse, len(kept))
+	for i, candidate := range kept {
+		candidates[i] = buildPaletteResponse(req, candidate.result)
+	}
+	response := candidates[0]
+	response.Candidates = candidates
+	return response
+}
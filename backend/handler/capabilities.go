@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CapabilitiesHandler 返回当前模型与已使用过的全部模型的能力档案；只读取已有结果，
+// 不会为此发送探测请求，当前模型尚未被使用时 model 为 null
+func CapabilitiesHandler(c *gin.Context) {
+	var current *ai.Profile
+	if p, ok := ai.ResolvedProfile(config.AppConfig.AIModel); ok {
+		current = &p
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"model":    current,
+		"profiles": ai.Profiles(),
+	})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/naming"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ColorInfoResponse struct {
+	Hex   string       `json:"hex"`
+	RGB   [3]uint8     `json:"rgb"`
+	OKLCH [3]float64   `json:"oklch"`
+	Lab   [3]float64   `json:"lab"`
+	Names naming.Names `json:"names"`
+}
+
+// ColorInfoHandler 返回单个颜色的数值表示及其在各色名表中最接近的名称
+func ColorInfoHandler(c *gin.Context) {
+	raw := strings.TrimSpace(c.Param("hex"))
+	hex, ok := colorutil.NormalizeHex("#" + strings.TrimPrefix(raw, "#"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid color: %s", raw)})
+		return
+	}
+	rgb := colorutil.MustParseHex(hex)
+	lch := colorutil.ToOKLCH(rgb)
+	lab := colorutil.ToLab(rgb)
+
+	c.JSON(http.StatusOK, ColorInfoResponse{
+		Hex:   hex,
+		RGB:   [3]uint8{rgb.R, rgb.G, rgb.B},
+		OKLCH: [3]float64{colorutil.Round(lch.L, 4), colorutil.Round(lch.C, 4), colorutil.Round(lch.H, 2)},
+		Lab:   [3]float64{colorutil.Round(lab.L, 2), colorutil.Round(lab.A, 2), colorutil.Round(lab.B, 2)},
+		Names: naming.Lookup(rgb),
+	})
+}
+
+// attachNames 为响应中的每个颜色附加最接近的 CSS、英文与中国传统色名
+func attachNames(response *ColorPaletteResponse) {
+	names, err := naming.LookupAll(response.Colors)
+	if err != nil {
+		log.Printf("[ERROR] Look up color names failed: %v", err)
+		return
+	}
+	response.Names = names
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/colorutil"
+	"ai-color-palette/naming"
+	"ai-color-palette/theme"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExplainPaletteRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+	// Prompt 可选的使用场景，帮助模型给出更贴切的解释
+	Prompt string `json:"prompt"`
+}
+
+type ExplainPaletteResponse struct {
+	Colors  []string         `json:"colors"`
+	Details []ai.ColorDetail `json:"details"`
+	Advice  string           `json:"advice"`
+	// Source 为 ai 或 heuristic，后者表示 AI 不可用时的本地推断
+	Source string `json:"source"`
+}
+
+// roleUsage 本地推断时各角色的面积权重，按 60/30/10 法则：中性色铺底，主次色居中，点缀色最少
+var roleUsage = map[string]float64{"neutral": 5, "primary": 2.5, "secondary": 1.2, "accent": 1}
+
+var roleRationale = map[string]string{
+	"primary":   "主色，用于主要按钮、链接与品牌标识等核心交互元素",
+	"secondary": "次色，用于次要按钮、标签与分区背景，衬托主色",
+	"accent":    "点缀色，小面积用于徽标、提示与需要强调的数据",
+	"neutral":   "中性色，作为背景与大面积底色，承载文字与内容",
+}
+
+// ExplainPaletteHandler 为外部来源的配色逐色给出名称、角色、建议用量与理由
+func ExplainPaletteHandler(c *gin.Context) {
+	var req ExplainPaletteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	colors := make([]string, len(req.Colors))
+	for i, color := range req.Colors {
+		normalized, ok := colorutil.NormalizeHex(color)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid color: %s", color)})
+			return
+		}
+		colors[i] = normalized
+	}
+	if len(colors) == 0 || len(colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 1 to 12 colors"})
+		return
+	}
+
+	response := ExplainPaletteResponse{Colors: colors, Source: "ai"}
+	explanation, err := ai.ExplainPalette(colors, req.Prompt)
+	if err != nil {
+		log.Printf("[ERROR] AI explain failed: %v, falling back to heuristic", err)
+		response.Details = describePalette(colors)
+		response.Advice = "AI 调用失败，以下为按明度与彩度推断的角色与用量，可作为参考。"
+		response.Source = "heuristic"
+	} else {
+		response.Details, response.Advice = explanation.Details, explanation.Advice
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// describePalette 不借助模型为配色生成逐色说明：角色沿用 theme.InferSemanticRoles，
+// 用量按角色权重分配，名称取最接近的中国传统色
+func describePalette(colors []string) []ai.ColorDetail {
+	roles := theme.InferSemanticRoles(colors)
+	byIndex := map[int]string{
+		roles.Neutral:   "neutral",
+		roles.Accent:    "accent",
+		roles.Secondary: "secondary",
+		roles.Primary:   "primary",
+	}
+
+	details := make([]ai.ColorDetail, len(colors))
+	total := 0.0
+	for i, color := range colors {
+		rgb := colorutil.MustParseHex(color)
+		lch := colorutil.ToOKLCH(rgb)
+		name, _ := naming.Nearest(rgb, naming.DatasetChinese)
+		role, ok := byIndex[i]
+		if !ok {
+			role = "secondary"
+			if lch.C < 0.05 {
+				role = "neutral"
+			}
+		}
+		details[i] = ai.ColorDetail{
+			Color:     color,
+			Name:      name.Name,
+			Role:      role,
+			Usage:     roleUsage[role],
+			Rationale: roleRationale[role],
+		}
+		total += roleUsage[role]
+	}
+	for i := range details {
+		details[i].Usage = math.Round(details[i].Usage/total*1000) / 10
+	}
+	return details
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"ai-color-palette/exporter"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportRequest struct {
+	Format string `json:"format" binding:"required"`
+	exporter.Palette
+}
+
+// ExportHandler 将配色导出为指定格式的文件
+func ExportHandler(c *gin.Context) {
+	var req ExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := exporter.Export(req.Format, &req.Palette)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "formats": exporter.Formats()})
+		return
+	}
+	log.Printf("[INFO] Exported palette as %s (%d bytes)", req.Format, len(file.Data))
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// ExportFormatsHandler 返回支持的导出格式
+func ExportFormatsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"formats": exporter.Formats()})
+}
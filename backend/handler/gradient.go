@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"ai-color-palette/gradient"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GradientRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+	// Positions 各颜色位置（0-1），为空时均匀分布
+	Positions []float64 `json:"positions"`
+	// Space 插值空间：srgb、oklab（默认）或 oklch
+	Space string `json:"space"`
+	// Hue OKLCH 插值的色相方向：shorter（默认）、longer、increasing、decreasing
+	Hue       string  `json:"hue"`
+	Midpoints bool    `json:"midpoints"`
+	Samples   int     `json:"samples"`
+	Angle     float64 `json:"angle"`
+}
+
+// GradientHandler 由配色颜色生成多段渐变，返回 CSS、SVG 定义与采样色
+func GradientHandler(c *gin.Context) {
+	req := GradientRequest{Angle: 90}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// 密集色标的计算量随颜色数平方增长
+	if len(req.Colors) < 2 || len(req.Colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 2 to 12 colors"})
+		return
+	}
+
+	g, err := gradient.Build(req.Colors, gradient.Options{
+		Space:     req.Space,
+		Hue:       req.Hue,
+		Positions: req.Positions,
+		Midpoints: req.Midpoints,
+		Samples:   req.Samples,
+		Angle:     req.Angle,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Generated %s gradient with %d stops", g.Space, len(g.Stops))
+
+	c.JSON(http.StatusOK, g)
+}
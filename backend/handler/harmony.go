@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"ai-color-palette/colortheory"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HarmonyRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+}
+
+type HarmonizeRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+	// Scheme 目标方案，为空时使用识别出的最接近方案
+	Scheme string `json:"scheme"`
+	// Tolerance 锚点两侧保留的色相范围（度），为空时使用默认值
+	Tolerance *float64 `json:"tolerance"`
+}
+
+// HarmonyHandler 识别配色遵循的经典方案及偏差
+func HarmonyHandler(c *gin.Context) {
+	var req HarmonyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Colors) == 0 || len(req.Colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 1 to 12 colors"})
+		return
+	}
+
+	detection, err := colortheory.Detect(req.Colors)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Detected harmony: %s", detection.Description)
+
+	c.JSON(http.StatusOK, detection)
+}
+
+// HarmonizeHandler 以最小的色相旋转使配色贴合方案，保持明度与彩度
+func HarmonizeHandler(c *gin.Context) {
+	var req HarmonizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Colors) == 0 || len(req.Colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 1 to 12 colors"})
+		return
+	}
+	tolerance := colortheory.DefaultTolerance
+	if req.Tolerance != nil {
+		tolerance = *req.Tolerance
+	}
+
+	result, err := colortheory.Harmonize(req.Colors, strings.ToLower(strings.TrimSpace(req.Scheme)), tolerance)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "schemes": colortheory.Schemes()})
+		return
+	}
+	log.Printf("[INFO] Harmonized palette to %s from %s", result.Scheme, result.Before.Description)
+
+	c.JSON(http.StatusOK, result)
+}
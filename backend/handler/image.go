@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/quantize"
+
+	"github.com/gin-gonic/gin"
+	_ "golang.org/x/image/webp"
+)
+
+// maxImageSize 上传图片大小上限
+const maxImageSize = 10 << 20
+
+// maxImagePixels 上传图片的像素数上限，压缩率很高的小文件解码后也可能占满内存
+const maxImagePixels = 16 << 20
+
+type ImagePaletteResponse struct {
+	ColorPaletteResponse
+	Weights []float64 `json:"weights"`
+}
+
+// decodeUploadedImage 读取表单中的图片文件，支持 PNG/JPEG/GIF/WebP
+func decodeUploadedImage(c *gin.Context, field string) (image.Image, string, error) {
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s is required", field)
+	}
+	if fileHeader.Size > maxImageSize {
+		return nil, "", fmt.Errorf("image exceeds %d MB", maxImageSize>>20)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	// 先只读取尺寸，超出上限时不再解码
+	size, _, err := image.DecodeConfig(io.LimitReader(file, maxImageSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+	if size.Width*size.Height > maxImagePixels {
+		return nil, "", fmt.Errorf("image is too large (max %d pixels)", maxImagePixels)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, "", err
+	}
+	img, format, err := image.Decode(io.LimitReader(file, maxImageSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode image: %w", err)
+	}
+	return img, format, nil
+}
+
+// requestImage 读取随提示词附带的图片：优先使用表单上传的 image 文件，其次是 base64 字段
+func requestImage(c *gin.Context, encoded string) (*ai.Image, error) {
+	if fileHeader, err := c.FormFile("image"); err == nil {
+		if fileHeader.Size > maxImageSize {
+			return nil, fmt.Errorf("image exceeds %d MB", maxImageSize>>20)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, maxImageSize))
+		if err != nil {
+			return nil, err
+		}
+		return ai.NewImage(data)
+	}
+	if encoded == "" {
+		return nil, nil
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > maxImageSize {
+		return nil, fmt.Errorf("image exceeds %d MB", maxImageSize>>20)
+	}
+	return ai.DecodeImage(encoded)
+}
+
+// ImagePaletteHandler 从上传的图片中提取配色，返回结构与生成接口一致，可继续微调
+func ImagePaletteHandler(c *gin.Context) {
+	img, format, err := decodeUploadedImage(c, "image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := quantize.Options{
+		Count:  5,
+		Method: strings.ToLower(c.DefaultPostForm("method", "kmeans")),
+		Dedupe: c.DefaultPostForm("dedupe", "true") != "false",
+	}
+	if v := c.PostForm("count"); v != "" {
+		count, err := strconv.Atoi(v)
+		if err != nil || count < 1 || count > 12 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "count must be between 1 and 12"})
+			return
+		}
+		opts.Count = count
+	}
+	if v := c.PostForm("min_distance"); v != "" {
+		if opts.MinDistance, err = strconv.ParseFloat(v, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_distance"})
+			return
+		}
+	}
+	if v := c.PostForm("chroma_weight"); v != "" {
+		if opts.ChromaWeight, err = strconv.ParseFloat(v, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chroma_weight"})
+			return
+		}
+	}
+
+	clusters, err := quantize.Palette(img, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Extracted %d colors from %s image (%s)", len(clusters), format, opts.Method)
+
+	colors := make([]string, len(clusters))
+	weights := make([]float64, len(clusters))
+	for i, cluster := range clusters {
+		colors[i] = cluster.Color
+		weights[i] = cluster.Weight
+	}
+	response := ImagePaletteResponse{
+		ColorPaletteResponse: ColorPaletteResponse{
+			Colors:      colors,
+			Advice:      imagePaletteAdvice(clusters),
+			Timestamp:   time.Now().Unix(),
+			Description: fmt.Sprintf("从上传图片中提取的 %d 色配色", len(colors)),
+		},
+		Weights: weights,
+	}
+	response.ID = savePalette("", &response.ColorPaletteResponse)
+	attachScales(&response.ColorPaletteResponse, c.PostForm("include_scales") == "true")
+	attachNames(&response.ColorPaletteResponse)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func imagePaletteAdvice(clusters []quantize.Cluster) string {
+	if len(clusters) == 0 {
+		return ""
+	}
+	advice := fmt.Sprintf("图片主色为 %s（约占 %.0f%%），可作为大面积背景或品牌主色", clusters[0].Color, clusters[0].Weight*100)
+	if len(clusters) > 1 {
+		last := clusters[len(clusters)-1]
+		advice += fmt.Sprintf("；%s 占比最小（约 %.0f%%），适合作为点缀色", last.Color, last.Weight*100)
+	}
+	return advice + "。"
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"ai-color-palette/extract"
+	"ai-color-palette/importer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize 导入文件大小上限
+const maxImportSize = 5 << 20
+
+// readUploadedFile 读取表单中的 file 字段
+func readUploadedFile(c *gin.Context) (string, []byte, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return "", nil, fmt.Errorf("file is required")
+	}
+	if fileHeader.Size > maxImportSize {
+		return "", nil, fmt.Errorf("file exceeds %d MB", maxImportSize>>20)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxImportSize))
+	if err != nil {
+		return "", nil, err
+	}
+	return fileHeader.Filename, data, nil
+}
+
+// ImportHandler 导入色板文件（ASE/GPL/KPL/Procreate/DTCG/CSS），返回可直接微调的5色方案
+func ImportHandler(c *gin.Context) {
+	filename, data, err := readUploadedFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := importer.Import(filename, data)
+	if err != nil {
+		log.Printf("[WARN] Import %s failed: %v", filename, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Imported %d colors from %s (%s)", len(result.Swatches), filename, result.Format)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExtractColorsHandler 从上传的 CSS/SVG/HTML 中收集颜色，合并相近色并给出使用报告
+func ExtractColorsHandler(c *gin.Context) {
+	filename, data, err := readUploadedFile(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var opts extract.Options
+	if v := c.PostForm("count"); v != "" {
+		if opts.Count, err = strconv.Atoi(v); err != nil || opts.Count < 1 || opts.Count > 32 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "count must be between 1 and 32"})
+			return
+		}
+	}
+	if v := c.PostForm("threshold"); v != "" {
+		if opts.Threshold, err = strconv.ParseFloat(v, 64); err != nil || opts.Threshold < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid threshold"})
+			return
+		}
+	}
+
+	kind := c.DefaultPostForm("kind", extract.DetectKind(filename, data))
+	report, err := extract.Analyze(kind, data, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Extracted %d unique colors from %s (%s)", report.UniqueColors, filename, kind)
+
+	c.JSON(http.StatusOK, report)
+}
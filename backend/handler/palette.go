@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -10,39 +11,97 @@ import (
 	"time"
 
 	"ai-color-palette/ai"
+	"ai-color-palette/billing"
+	"ai-color-palette/colortheory"
+	"ai-color-palette/dataviz"
+	"ai-color-palette/naming"
+	"ai-color-palette/score"
+	"ai-color-palette/store"
+	"ai-color-palette/theme"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ColorPaletteRequest struct {
-	Prompt string `json:"prompt" binding:"required"`
+	Prompt string `json:"prompt" form:"prompt" binding:"required"`
+	// Image 可选的参考图片（base64 或 data URL），也可通过表单字段 image 上传
+	Image string `json:"image" form:"-"`
+	// IncludeScales 为 true 时在响应中附带每个颜色的色阶
+	IncludeScales bool `json:"include_scales" form:"include_scales"`
+	// Purpose 数据可视化用途：sequential、diverging 或 categorical，为空时生成品牌配色
+	Purpose string `json:"purpose" form:"purpose"`
+	// Candidates 候选配色数（1–6），大于 1 时并行生成、去重并按评分排序
+	Candidates int `json:"candidates" form:"candidates"`
+	// Agentic 为 true 时模型可先调用对比度与色盲模拟工具自检，再提交配色
+	Agentic bool `json:"agentic" form:"agentic"`
+	// NoCache 为 true 时不使用缓存的结果，重新调用模型
+	NoCache bool `json:"no_cache" form:"no_cache"`
 }
 
 type SingleColorRequest struct {
-	Prompt      string   `json:"prompt" binding:"required"`
-	BaseColors  []string `json:"base_colors" binding:"required"`
-	TargetIndex int      `json:"target_index" binding:"required"`
+	Prompt        string   `json:"prompt" form:"prompt" binding:"required"`
+	BaseColors    []string `json:"base_colors" form:"base_colors" binding:"required"`
+	TargetIndex   int      `json:"target_index" form:"target_index" binding:"required"`
+	Image         string   `json:"image" form:"-"`
+	IncludeScales bool     `json:"include_scales" form:"include_scales"`
+	NoCache       bool     `json:"no_cache" form:"no_cache"`
 }
 
 type ColorPaletteResponse struct {
+	ID          string   `json:"id,omitempty"`
 	Colors      []string `json:"colors"`
 	Advice      string   `json:"advice"`
 	Timestamp   int64    `json:"timestamp"`
 	Description string   `json:"description"`
+	// Details 逐色的名称、角色、建议用量与理由
+	Details []ai.ColorDetail `json:"details,omitempty"`
+	// Names 每个颜色在 CSS、英文与中国传统色名表中最接近的名称
+	Names []naming.Names `json:"names,omitempty"`
+	// Score 服务端对配色的评分
+	Score *score.Score `json:"score,omitempty"`
+	// Scales 每个颜色的色阶，仅在请求 include_scales 时返回
+	Scales []theme.Scale `json:"scales,omitempty"`
+	// Purpose 与 Validation 仅在按可视化用途生成时返回，Validation 为服务端校验结果
+	Purpose    string          `json:"purpose,omitempty"`
+	Validation *dataviz.Report `json:"validation,omitempty"`
+	// Repairs 解析模型输出时应用的修复项，便于观察模型输出质量
+	Repairs []string `json:"repairs,omitempty"`
+	// Candidates 请求多个候选时按评分从高到低排列的全部候选，顶层字段与第一个候选相同
+	Candidates []ColorPaletteResponse `json:"candidates,omitempty"`
+	// Cached 结果来自相同请求的缓存，未重新调用模型
+	Cached bool `json:"cached"`
 }
 
 type RefinePaletteRequest struct {
-	CurrentColors []string `json:"current_colors" binding:"required"`
-	Prompt        string   `json:"prompt" binding:"required"`
+	CurrentColors []string `json:"current_colors" form:"current_colors" binding:"required"`
+	Prompt        string   `json:"prompt" form:"prompt" binding:"required"`
+	Image         string   `json:"image" form:"-"`
+	IncludeScales bool     `json:"include_scales" form:"include_scales"`
+	Agentic       bool     `json:"agentic" form:"agentic"`
+	NoCache       bool     `json:"no_cache" form:"no_cache"`
 }
 
 // GeneratePaletteHandler 使用AI生成配色方案，失败时降级到随机生成
 func GeneratePaletteHandler(c *gin.Context) {
 	var req ColorPaletteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	image, err := requestImage(c, req.Image)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Purpose = strings.ToLower(strings.TrimSpace(req.Purpose))
+	if req.Purpose != "" && !dataviz.IsPurpose(req.Purpose) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("purpose must be one of: %s", strings.Join(dataviz.Purposes, ", "))})
+		return
+	}
+	if req.Candidates < 0 || req.Candidates > ai.MaxCandidates {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("candidates must be between 1 and %d", ai.MaxCandidates)})
+		return
+	}
 
 	// 尝试使用AI生成配色
 	log.Printf("[INFO] Using %s to create colors:\n", req.Prompt)
@@ -52,37 +111,83 @@ func GeneratePaletteHandler(c *gin.Context) {
 		response := ColorPaletteResponse{
 			Colors:      colors,
 			Advice:      "你找到了隐藏彩蛋~这是专属于作者烧鸡的配色方案，烧鸡yyds！",
+			Details:     describePalette(colors),
 			Timestamp:   time.Now().Unix(),
 			Description: "你找到了隐藏彩蛋~这是专属于作者烧鸡的配色方案！",
 		}
+		response.ID = savePalette(req.Prompt, &response)
+		attachScales(&response, req.IncludeScales)
+		attachNames(&response)
 		c.JSON(http.StatusOK, response)
 		return
 	}
-	result, err := ai.GenerateColorPalette(req.Prompt)
+	if req.Candidates > 1 {
+		c.JSON(http.StatusOK, generateCandidates(&req, image))
+		return
+	}
+	result, err := ai.GenerateColorPalette(req.Prompt, ai.Options{Image: image, Purpose: req.Purpose, Agentic: req.Agentic, NoCache: req.NoCache})
 	if err != nil {
-		log.Printf("[ERROR] AI generation failed: %v, falling back to random generation", err)
-		// 降级到随机生成
-		rand.Seed(time.Now().UnixNano())
-		result = &ai.PaletteResult{
-			Colors: generateRandomColors(5, req.Prompt),
-			Advice: "由于网络原因，AI调用失败。本次为随机生成配色，可作为灵感草案使用。建议在主色与辅色之间调整明度对比以提升层次感。",
+		result = fallbackPalette(&req, err)
+	}
+
+	c.JSON(http.StatusOK, buildPaletteResponse(&req, result))
+}
+
+// fallbackPalette AI 生成失败时的降级配色：可视化用途按模板离线生成，否则随机生成
+func fallbackPalette(req *ColorPaletteRequest, err error) *ai.PaletteResult {
+	if req.Purpose != "" {
+		log.Printf("[ERROR] AI generation failed: %v, falling back to %s template", err, req.Purpose)
+		return &ai.PaletteResult{
+			Colors: dataviz.Fallback(req.Purpose, 5, req.Prompt),
+			Advice: "由于网络原因，AI调用失败。本次按可视化用途离线生成配色，已满足基本的明度与区分度要求，可直接用于图表草稿。",
 		}
 	}
+	log.Printf("[ERROR] AI generation failed: %v, falling back to random generation", err)
+	// 降级到随机生成
+	rand.Seed(time.Now().UnixNano())
+	advice := "由于网络原因，AI调用失败。本次为随机生成配色，可作为灵感草案使用。建议在主色与辅色之间调整明度对比以提升层次感。"
+	if errors.Is(err, billing.ErrBudgetExceeded) {
+		advice = "本月 AI 调用额度已用完，本次为离线随机生成配色，可作为灵感草案使用。建议在主色与辅色之间调整明度对比以提升层次感。"
+	}
+	return &ai.PaletteResult{
+		Colors: generateRandomColors(5, req.Prompt),
+		Advice: advice,
+	}
+}
 
+// buildPaletteResponse 为生成结果补全说明、校验、评分与色名并保存
+func buildPaletteResponse(req *ColorPaletteRequest, result *ai.PaletteResult) ColorPaletteResponse {
 	response := ColorPaletteResponse{
 		Colors:      result.Colors,
 		Advice:      result.Advice,
+		Details:     colorDetails(result),
+		Repairs:     result.Repairs,
+		Cached:      result.Cached,
 		Timestamp:   time.Now().Unix(),
 		Description: fmt.Sprintf("根据提示词 '%s' 生成的配色方案", req.Prompt),
+		Purpose:     req.Purpose,
 	}
-
-	c.JSON(http.StatusOK, response)
+	if req.Purpose != "" {
+		var err error
+		if response.Validation, err = dataviz.Validate(req.Purpose, response.Colors); err != nil {
+			log.Printf("[ERROR] Validate %s palette failed: %v", req.Purpose, err)
+		}
+	}
+	response.ID = savePalette(req.Prompt, &response)
+	attachScales(&response, req.IncludeScales)
+	attachNames(&response)
+	return response
 }
 
 // RegenerateSingleColorHandler 仅重新生成指定位置的颜色
 func RegenerateSingleColorHandler(c *gin.Context) {
 	var req SingleColorRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	image, err := requestImage(c, req.Image)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -108,7 +213,7 @@ func RegenerateSingleColorHandler(c *gin.Context) {
 		normalized = append(normalized, candidate)
 	}
 	log.Printf("[INFO] Using %s to replace single color:\n", req.Prompt)
-	result, err := ai.GeneratePaletteWithSingleColor(normalized, req.TargetIndex, req.Prompt)
+	result, err := ai.GeneratePaletteWithSingleColor(normalized, req.TargetIndex, req.Prompt, ai.Options{Image: image, NoCache: req.NoCache})
 	if err != nil {
 		log.Printf("[ERROR] AI single color generation failed: %v, fallback to replace target only", err)
 		rand.Seed(time.Now().UnixNano())
@@ -118,6 +223,9 @@ func RegenerateSingleColorHandler(c *gin.Context) {
 			Colors: normalized,
 			Advice: "AI 调用失败，已为指定位置生成备选颜色。建议再尝试一次以获得更佳效果。",
 		}
+		if errors.Is(err, billing.ErrBudgetExceeded) {
+			result.Advice = "本月 AI 调用额度已用完，已为指定位置离线生成备选颜色。"
+		}
 	}
 
 	// 再次确保只有目标位置被替换
@@ -131,9 +239,15 @@ func RegenerateSingleColorHandler(c *gin.Context) {
 	response := ColorPaletteResponse{
 		Colors:      result.Colors,
 		Advice:      result.Advice,
+		Details:     colorDetails(result),
+		Repairs:     result.Repairs,
+		Cached:      result.Cached,
 		Timestamp:   time.Now().Unix(),
 		Description: fmt.Sprintf("针对第%d个颜色的定向微调", req.TargetIndex+1),
 	}
+	response.ID = savePalette(req.Prompt, &response)
+	attachScales(&response, req.IncludeScales)
+	attachNames(&response)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -141,12 +255,44 @@ func RegenerateSingleColorHandler(c *gin.Context) {
 // RefinePaletteHandler 基于现有配色方案进行微调
 func RefinePaletteHandler(c *gin.Context) {
 	var req RefinePaletteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	image, err := requestImage(c, req.Image)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	result, err := ai.RefinePalette(req.CurrentColors, req.Prompt)
+	// 用户要求“更和谐”时把识别出的方案告诉模型，并在结果上做一次色相调和
+	prompt := req.Prompt
+	harmonize := false
+	if wantsHarmony(req.Prompt) {
+		detection, err := colortheory.Detect(req.CurrentColors)
+		harmonize = err == nil
+		if harmonize && detection.Scheme != colortheory.Neutral {
+			prompt += fmt.Sprintf("。当前配色%s，请在保持明度与彩度的前提下让色相更贴合该方案", detection.Description)
+		}
+	}
+
+	result, err := ai.RefinePalette(req.CurrentColors, prompt, ai.Options{Image: image, Agentic: req.Agentic, NoCache: req.NoCache})
+	if err != nil && harmonize {
+		log.Printf("[ERROR] Refine palette failed: %v, fallback to local harmonization", err)
+		advice := "AI 调用失败，已在本地将色相旋转到最接近的经典配色方案，明度与彩度保持不变。"
+		if errors.Is(err, billing.ErrBudgetExceeded) {
+			advice = "本月 AI 调用额度已用完，已在本地将色相旋转到最接近的经典配色方案，明度与彩度保持不变。"
+		}
+		result, err = &ai.PaletteResult{Colors: req.CurrentColors, Advice: advice}, nil
+	}
+	if errors.Is(err, billing.ErrBudgetExceeded) {
+		// 额度用完时不再调用模型，原样返回当前配色
+		log.Printf("[WARN] Refine palette skipped: %v, returning current colors", err)
+		result, err = &ai.PaletteResult{
+			Colors: req.CurrentColors,
+			Advice: "本月 AI 调用额度已用完，本次未做调整，配色保持不变。可稍后再试，或手动微调个别颜色。",
+		}, nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Refine palette failed: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refine palette"})
@@ -154,16 +300,83 @@ func RefinePaletteHandler(c *gin.Context) {
 	}
 	log.Printf("[INFO] Using %s to refine colors:\n", req.Prompt)
 
+	description := fmt.Sprintf("基于提示词 '%s' 调整的配色", req.Prompt)
+	if harmonize {
+		harmonized, err := colortheory.Harmonize(result.Colors, "", colortheory.DefaultTolerance)
+		if err != nil {
+			log.Printf("[ERROR] Harmonize refined palette failed: %v", err)
+		} else {
+			result.Colors = harmonized.Colors
+			for _, shift := range harmonized.Shifts {
+				if shift != 0 {
+					// 模型给出的色名与说明针对的是调和前的颜色，改为按新颜色本地推断
+					result.Details = nil
+					break
+				}
+			}
+			if harmonized.Scheme != colortheory.Neutral {
+				description += fmt.Sprintf("，已调和为%s配色", colortheory.Label(harmonized.Scheme))
+			}
+		}
+	}
+
 	response := ColorPaletteResponse{
 		Colors:      result.Colors,
 		Advice:      result.Advice,
+		Details:     colorDetails(result),
+		Repairs:     result.Repairs,
+		Cached:      result.Cached,
 		Timestamp:   time.Now().Unix(),
-		Description: fmt.Sprintf("基于提示词 '%s' 调整的配色", req.Prompt),
+		Description: description,
 	}
+	response.ID = savePalette(req.Prompt, &response)
+	attachScales(&response, req.IncludeScales)
+	attachNames(&response)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// wantsHarmony 判断微调意见是否要求让配色更和谐
+func wantsHarmony(prompt string) bool {
+	lower := strings.ToLower(prompt)
+	for _, keyword := range []string{"和谐", "协调", "harmon"} {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// colorDetails 优先使用模型给出的逐色说明，缺失或与颜色数量不符时本地推断
+func colorDetails(result *ai.PaletteResult) []ai.ColorDetail {
+	if len(result.Details) == len(result.Colors) {
+		details := make([]ai.ColorDetail, len(result.Details))
+		copy(details, result.Details)
+		for i := range details {
+			details[i].Color = result.Colors[i]
+		}
+		return details
+	}
+	return describePalette(result.Colors)
+}
+
+// savePalette 为生成结果评分并保存，供分享与打包下载使用
+func savePalette(prompt string, response *ColorPaletteResponse) string {
+	s, err := score.Evaluate(response.Colors)
+	if err != nil {
+		log.Printf("[ERROR] Score palette failed: %v", err)
+	}
+	response.Score = s
+	return store.Save(&store.Palette{
+		Colors:      response.Colors,
+		Advice:      response.Advice,
+		Prompt:      prompt,
+		Description: response.Description,
+		Score:       s,
+		CreatedAt:   time.Unix(response.Timestamp, 0),
+	})
+}
+
 // 生成随机配色
 func generateRandomColors(count int, seed string) []string {
 	colors := []string{}
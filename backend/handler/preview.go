@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"ai-color-palette/preview"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PreviewRequest struct {
+	Colors   []string       `json:"colors" binding:"required"`
+	Roles    map[string]int `json:"roles"`
+	Template string         `json:"template"`
+	Format   string         `json:"format"`
+}
+
+// PreviewHandler 用配色渲染示例界面（落地页/后台/移动端），输出 SVG 或 PNG
+func PreviewHandler(c *gin.Context) {
+	var req PreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Template == "" {
+		req.Template = "landing"
+	}
+
+	roles, err := preview.ResolveRoles(req.Colors, req.Roles)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "roles": preview.RoleNames})
+		return
+	}
+	data, contentType, err := preview.Render(req.Template, roles, strings.ToLower(req.Format))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "templates": preview.Templates()})
+		return
+	}
+	log.Printf("[INFO] Rendered %s preview (%s, %d bytes)", req.Template, contentType, len(data))
+
+	c.Data(http.StatusOK, contentType, data)
+}
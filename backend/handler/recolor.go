@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/recolor"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecolorHandler 用目标配色重新着色上传的图片，返回 PNG。
+// 表单字段：image（图片）、colors（可重复或逗号分隔）、match（luminance/optimal）、strength（0-1）
+func RecolorHandler(c *gin.Context) {
+	img, format, err := decodeUploadedImage(c, "image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var colors []string
+	for _, field := range c.PostFormArray("colors") {
+		for _, color := range strings.Split(field, ",") {
+			normalized, ok := colorutil.NormalizeHex(color)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid color: %s", color)})
+				return
+			}
+			colors = append(colors, normalized)
+		}
+	}
+	if len(colors) < 2 || len(colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 2-12 hex values"})
+		return
+	}
+
+	opts := recolor.Options{Match: strings.ToLower(c.DefaultPostForm("match", recolor.MatchLuminance)), Strength: 1}
+	if v := c.PostForm("strength"); v != "" {
+		if opts.Strength, err = strconv.ParseFloat(v, 64); err != nil || opts.Strength <= 0 || opts.Strength > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "strength must be in (0, 1]"})
+			return
+		}
+	}
+
+	out, pairs, err := recolor.Apply(img, colors, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		log.Printf("[ERROR] Encode recolored image failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode image"})
+		return
+	}
+	log.Printf("[INFO] Recolored %s image (%dx%d) with %d colors by %s", format, out.Rect.Dx(), out.Rect.Dy(), len(colors), opts.Match)
+
+	if mapping, err := json.Marshal(pairs); err == nil {
+		c.Header("X-Recolor-Mapping", string(mapping))
+	}
+	c.Data(http.StatusOK, "image/png", buf.Bytes())
+}
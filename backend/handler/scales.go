@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"ai-color-palette/theme"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScalesRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+	// Kind material、tailwind 或 all（默认）
+	Kind string `json:"kind"`
+}
+
+// ScalesHandler 为配色中每个颜色生成 Material 色调阶与 Tailwind 50–950 色阶
+func ScalesHandler(c *gin.Context) {
+	var req ScalesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scales, err := theme.Scales(req.Colors, req.Kind)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Generated scales for %d colors", len(scales))
+
+	c.JSON(http.StatusOK, gin.H{"scales": scales})
+}
+
+// attachScales 请求带 include_scales 时为响应附加色阶
+func attachScales(response *ColorPaletteResponse, include bool) {
+	if !include {
+		return
+	}
+	scales, err := theme.Scales(response.Colors)
+	if err != nil {
+		log.Printf("[ERROR] Generate scales failed: %v", err)
+		return
+	}
+	response.Scales = scales
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"ai-color-palette/score"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScoreRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+}
+
+// ScoreHandler 从明度阶梯、色相和谐、可区分度、对比度、色盲可辨性与彩度平衡为配色打分
+func ScoreHandler(c *gin.Context) {
+	var req ScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// 评分含两两比较，限制数量与 /api/explain-palette 一致
+	if len(req.Colors) < 2 || len(req.Colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 2 to 12 colors"})
+		return
+	}
+
+	s, err := score.Evaluate(req.Colors)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Scored palette of %d colors: %.1f", len(req.Colors), s.Overall)
+
+	c.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"ai-color-palette/exporter"
+	"ai-color-palette/store"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPaletteHandler 按ID返回已保存的配色
+func GetPaletteHandler(c *gin.Context) {
+	p, ok := store.Get(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "palette not found"})
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// BundleHandler 将配色的全部导出格式打包为 zip 流式返回；先生成全部格式，
+// 任一格式导出失败时在发送响应头之前返回错误
+func BundleHandler(c *gin.Context) {
+	p, ok := store.Get(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "palette not found"})
+		return
+	}
+
+	palette := &exporter.Palette{
+		Colors: append([]string{}, p.Colors...),
+		Advice: p.Advice,
+		Prompt: p.Prompt,
+	}
+	bundle, err := exporter.NewBundle(palette, exporter.BundleInfo{
+		ID:          p.ID,
+		Description: p.Description,
+		CreatedAt:   p.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Build bundle %s failed: %v", p.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build bundle"})
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "paletteflow-"+p.ID+".zip"))
+	c.Status(http.StatusOK)
+	if err := bundle.Write(c.Writer); err != nil {
+		// 响应头已发送，只能记录错误（通常是客户端断开）
+		log.Printf("[ERROR] Write bundle %s failed: %v", p.ID, err)
+		return
+	}
+	log.Printf("[INFO] Bundle for palette %s sent", p.ID)
+}
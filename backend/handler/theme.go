@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"ai-color-palette/ai"
+	"ai-color-palette/theme"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TerminalThemeRequest struct {
+	Colors  []string `json:"colors" binding:"required"`
+	Advice  string   `json:"advice"`
+	Name    string   `json:"name"`
+	Variant string   `json:"variant"`
+}
+
+// TerminalThemeHandler 将配色映射为终端/编辑器主题，并返回对比度检查结果
+func TerminalThemeHandler(c *gin.Context) {
+	var req TerminalThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	t, err := theme.GenerateTerminal(req.Colors, theme.TerminalOptions{
+		Name:        req.Name,
+		Description: req.Advice,
+		Variant:     req.Variant,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Generated %s terminal theme from %d colors", t.Variant, len(req.Colors))
+
+	c.JSON(http.StatusOK, t)
+}
+
+type SemanticThemeRequest struct {
+	// Colors 与 Prompt 至少提供一个；只给 Prompt 时先由 AI 生成配色
+	Colors []string             `json:"colors"`
+	Prompt string               `json:"prompt"`
+	Roles  *theme.SemanticRoles `json:"roles"`
+	// UseAI 为 true 时由模型分配角色，失败则退回本地推断
+	UseAI bool `json:"use_ai"`
+}
+
+type SemanticThemeResponse struct {
+	*theme.SemanticTheme
+	Advice     string `json:"advice,omitempty"`
+	RoleSource string `json:"role_source"`
+	RoleReason string `json:"role_reason,omitempty"`
+}
+
+// SemanticThemeHandler 将配色转换为带浅色/深色模式的语义化界面主题
+func SemanticThemeHandler(c *gin.Context) {
+	var req SemanticThemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	prompt := strings.TrimSpace(req.Prompt)
+	if len(req.Colors) == 0 && prompt == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors or prompt is required"})
+		return
+	}
+
+	response := SemanticThemeResponse{RoleSource: "heuristic"}
+	colors := req.Colors
+	if len(colors) == 0 {
+		result, err := ai.GenerateColorPalette(prompt, ai.Options{})
+		if err != nil {
+			log.Printf("[ERROR] AI generation failed: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate palette"})
+			return
+		}
+		colors, response.Advice = result.Colors, result.Advice
+	}
+
+	roles := req.Roles
+	if roles != nil {
+		response.RoleSource = "request"
+	} else if req.UseAI {
+		assigned, err := ai.AssignRoles(colors, prompt)
+		if err != nil {
+			log.Printf("[ERROR] AI role assignment failed: %v, falling back to heuristic", err)
+		} else {
+			roles = &theme.SemanticRoles{
+				Primary:   assigned.Primary,
+				Secondary: assigned.Secondary,
+				Accent:    assigned.Accent,
+				Neutral:   assigned.Neutral,
+			}
+			response.RoleSource, response.RoleReason = "ai", assigned.Reason
+		}
+	}
+
+	t, err := theme.GenerateSemantic(colors, roles)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response.SemanticTheme = t
+	log.Printf("[INFO] Generated semantic theme from %d colors (roles: %s)", len(colors), response.RoleSource)
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-color-palette/wallpaper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WallpaperRequest struct {
+	Colors []string `json:"colors" binding:"required"`
+	// Style mesh（默认）、blobs、lowpoly 或 stripes
+	Style  string `json:"style"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Format string `json:"format"`
+	// Seed 为空时随机选取，实际使用的种子通过 X-Wallpaper-Seed 响应头返回
+	Seed *int64 `json:"seed"`
+}
+
+// WallpaperHandler 用配色渲染装饰背景图，输出 SVG 或 PNG；相同种子结果相同
+func WallpaperHandler(c *gin.Context) {
+	req := WallpaperRequest{Style: wallpaper.StyleMesh, Width: 1920, Height: 1080}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// 光斑数随颜色数增加，限制数量避免渲染耗时失控
+	if len(req.Colors) == 0 || len(req.Colors) > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "colors must contain 1 to 12 colors"})
+		return
+	}
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	scene, err := wallpaper.Build(req.Colors, wallpaper.Options{
+		Style:  req.Style,
+		Width:  req.Width,
+		Height: req.Height,
+		Seed:   seed,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "styles": wallpaper.Styles()})
+		return
+	}
+	data, contentType, err := scene.Encode(strings.ToLower(req.Format))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("[INFO] Rendered %s wallpaper %dx%d (%s, %d bytes)", req.Style, req.Width, req.Height, contentType, len(data))
+
+	c.Header("X-Wallpaper-Seed", strconv.FormatInt(seed, 10))
+	c.Data(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf16"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	aseBlockColor      = 0x0001
+	aseBlockGroupStart = 0xC001
+)
+
+// parseASE 解析 Adobe Swatch Exchange 二进制文件
+func parseASE(data []byte) (string, []Swatch, error) {
+	r := bytes.NewReader(data)
+	var header struct {
+		Magic  [4]byte
+		Major  uint16
+		Minor  uint16
+		Blocks uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return "", nil, fmt.Errorf("read header: %w", err)
+	}
+
+	var paletteName string
+	var swatches []Swatch
+	for i := uint32(0); i < header.Blocks; i++ {
+		var blockType uint16
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &blockType); err != nil {
+			return "", nil, fmt.Errorf("read block %d: %w", i, err)
+		}
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return "", nil, fmt.Errorf("read block %d: %w", i, err)
+		}
+		// 块长度来自文件，分配前先确认剩余数据足够
+		if length > uint32(r.Len()) {
+			return "", nil, fmt.Errorf("block %d length %d exceeds remaining %d bytes", i, length, r.Len())
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return "", nil, fmt.Errorf("read block %d body: %w", i, err)
+		}
+
+		switch blockType {
+		case aseBlockGroupStart:
+			if name, _, err := readASEName(body); err == nil && paletteName == "" {
+				paletteName = name
+			}
+		case aseBlockColor:
+			swatch, err := parseASEColor(body)
+			if err != nil {
+				return "", nil, fmt.Errorf("block %d: %w", i, err)
+			}
+			if swatch != nil {
+				swatches = append(swatches, *swatch)
+			}
+		}
+	}
+	return paletteName, swatches, nil
+}
+
+// readASEName 读取 UTF-16BE 名称（长度含结尾 0），返回名称和消耗的字节数
+func readASEName(body []byte) (string, int, error) {
+	if len(body) < 2 {
+		return "", 0, fmt.Errorf("truncated name")
+	}
+	count := int(binary.BigEndian.Uint16(body))
+	end := 2 + count*2
+	if len(body) < end {
+		return "", 0, fmt.Errorf("truncated name")
+	}
+	units := make([]uint16, 0, count)
+	for i := 0; i < count; i++ {
+		u := binary.BigEndian.Uint16(body[2+i*2:])
+		if u == 0 {
+			break
+		}
+		units = append(units, u)
+	}
+	return string(utf16.Decode(units)), end, nil
+}
+
+func parseASEColor(body []byte) (*Swatch, error) {
+	name, offset, err := readASEName(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < offset+4 {
+		return nil, fmt.Errorf("truncated color model")
+	}
+	model := strings.TrimSpace(string(body[offset : offset+4]))
+	offset += 4
+
+	channels := map[string]int{"RGB": 3, "CMYK": 4, "LAB": 3, "Gray": 1}
+	count, ok := channels[model]
+	if !ok {
+		return nil, nil
+	}
+	if len(body) < offset+count*4 {
+		return nil, fmt.Errorf("truncated %s values", model)
+	}
+	v := make([]float64, count)
+	for i := range v {
+		v[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(body[offset+i*4:])))
+	}
+
+	var c colorutil.RGB
+	switch model {
+	case "RGB":
+		c = colorutil.RGB{R: unitByte(v[0]), G: unitByte(v[1]), B: unitByte(v[2])}
+	case "CMYK":
+		c = colorutil.FromCMYK(v[0], v[1], v[2], v[3])
+	case "LAB":
+		// ASE 中 L 以 0-1 存储
+		c = colorutil.Lab{L: v[0] * 100, A: v[1], B: v[2]}.RGB()
+	case "Gray":
+		c = colorutil.RGB{R: unitByte(v[0]), G: unitByte(v[0]), B: unitByte(v[0])}
+	}
+	return &Swatch{Name: name, Color: c.Hex()}, nil
+}
+
+func unitByte(v float64) uint8 {
+	return uint8(math.Round(math.Min(1, math.Max(0, v)) * 255))
+}
@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"regexp"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+var (
+	// 自定义属性、SCSS/Less 变量声明
+	cssVariableRegex = regexp.MustCompile(`(?m)(--[\w-]+|\$[\w-]+|@[\w-]+)\s*:\s*([^;{}\n]+)`)
+	cssLiteralRegex  = regexp.MustCompile(`#[0-9A-Fa-f]{3,8}\b|(?:rgba?|hsla?)\([^)]*\)`)
+)
+
+// parseCSS 从 CSS/SCSS 中提取颜色，优先使用变量声明的名称
+func parseCSS(data []byte) (string, []Swatch, error) {
+	text := string(data)
+	var swatches []Swatch
+	for _, m := range cssVariableRegex.FindAllStringSubmatch(text, -1) {
+		literal := cssLiteralRegex.FindString(m[2])
+		if literal == "" {
+			continue
+		}
+		if c, ok := colorutil.ParseCSSColor(literal); ok {
+			name := strings.TrimLeft(m[1], "-$@")
+			swatches = append(swatches, Swatch{Name: name, Color: c.Hex()})
+		}
+	}
+	for _, literal := range cssLiteralRegex.FindAllString(text, -1) {
+		if c, ok := colorutil.ParseCSSColor(literal); ok {
+			swatches = append(swatches, Swatch{Color: c.Hex()})
+		}
+	}
+	return "", swatches, nil
+}
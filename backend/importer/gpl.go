@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// parseGPL 解析 GIMP/Inkscape 调色板（.gpl）
+func parseGPL(data []byte) (string, []Swatch, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var name string
+	var swatches []Swatch
+	for line := 0; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 0 || text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "Name:") {
+			name = strings.TrimSpace(strings.TrimPrefix(text, "Name:"))
+			continue
+		}
+		if strings.HasPrefix(text, "Columns:") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return "", nil, fmt.Errorf("line %d: expected R G B values", line+1)
+		}
+		var rgb [3]uint8
+		for i := 0; i < 3; i++ {
+			v, err := strconv.Atoi(fields[i])
+			if err != nil || v < 0 || v > 255 {
+				return "", nil, fmt.Errorf("line %d: invalid channel %q", line+1, fields[i])
+			}
+			rgb[i] = uint8(v)
+		}
+		c := colorutil.RGB{R: rgb[0], G: rgb[1], B: rgb[2]}
+		swatches = append(swatches, Swatch{Name: strings.Join(fields[3:], " "), Color: c.Hex()})
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	return name, swatches, nil
+}
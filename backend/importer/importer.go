@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// PaletteSize 现有生成/微调接口要求的颜色数量
+const PaletteSize = 5
+
+// Swatch 从文件中读取到的单个颜色
+type Swatch struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Result 导入结果，Colors/Names 可直接作为 current_colors 或 base_colors 使用
+type Result struct {
+	Format   string   `json:"format"`
+	Name     string   `json:"name,omitempty"`
+	Swatches []Swatch `json:"swatches"`
+	Colors   []string `json:"colors"`
+	Names    []string `json:"names"`
+	Notes    []string `json:"notes,omitempty"`
+}
+
+type parser func(data []byte) (name string, swatches []Swatch, err error)
+
+var parsers = map[string]parser{
+	"ase":       parseASE,
+	"gpl":       parseGPL,
+	"kpl":       parseKPL,
+	"procreate": parseProcreate,
+	"tokens":    parseTokens,
+	"css":       parseCSS,
+}
+
+// Detect 根据文件内容（必要时参考扩展名）判断格式
+func Detect(filename string, data []byte) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("ASEF")):
+		return "ase", nil
+	case bytes.HasPrefix(trimmed, []byte("GIMP Palette")):
+		return "gpl", nil
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return detectZip(data)
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return "tokens", nil
+	case ext == ".css" || ext == ".scss" || ext == ".sass" || ext == ".less":
+		return "css", nil
+	}
+	// 其余文本按 CSS/SCSS 处理，只要能找到颜色即可
+	if bytes.ContainsAny(trimmed, "#(") {
+		return "css", nil
+	}
+	return "", fmt.Errorf("unrecognized palette file format")
+}
+
+// Import 识别格式并解析颜色，归一化为5色方案
+func Import(filename string, data []byte) (*Result, error) {
+	format, err := Detect(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	name, swatches, err := parsers[format](data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", format, err)
+	}
+	swatches = dedupe(swatches)
+	if len(swatches) == 0 {
+		return nil, fmt.Errorf("no colors found in %s file", format)
+	}
+
+	result := &Result{Format: format, Name: name, Swatches: swatches}
+	selected := swatches
+	if len(swatches) > PaletteSize {
+		selected = selectDistinct(swatches, PaletteSize)
+		result.Notes = append(result.Notes, fmt.Sprintf("file contains %d colors, selected the %d most distinct", len(swatches), PaletteSize))
+	} else if len(swatches) < PaletteSize {
+		selected = padSwatches(swatches, PaletteSize)
+		result.Notes = append(result.Notes, fmt.Sprintf("file contains %d colors, added %d lightness variants", len(swatches), PaletteSize-len(swatches)))
+	}
+	for _, s := range selected {
+		result.Colors = append(result.Colors, s.Color)
+		result.Names = append(result.Names, s.Name)
+	}
+	return result, nil
+}
+
+func dedupe(swatches []Swatch) []Swatch {
+	seen := make(map[string]bool)
+	out := make([]Swatch, 0, len(swatches))
+	for _, s := range swatches {
+		if seen[s.Color] {
+			continue
+		}
+		seen[s.Color] = true
+		if strings.TrimSpace(s.Name) == "" {
+			s.Name = fmt.Sprintf("Color %d", len(out)+1)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// selectDistinct 以最远点采样选出 n 个差异最大的颜色，并保持原有顺序
+func selectDistinct(swatches []Swatch, n int) []Swatch {
+	labs := make([]colorutil.OKLab, len(swatches))
+	for i, s := range swatches {
+		labs[i] = colorutil.ToOKLab(colorutil.MustParseHex(s.Color))
+	}
+	chosen := map[int]bool{0: true}
+	minDist := make([]float64, len(swatches))
+	for i := range swatches {
+		minDist[i] = okDistance(labs[i], labs[0])
+	}
+	for len(chosen) < n {
+		next := -1
+		for i := range swatches {
+			if !chosen[i] && (next < 0 || minDist[i] > minDist[next]) {
+				next = i
+			}
+		}
+		chosen[next] = true
+		for i := range swatches {
+			if d := okDistance(labs[i], labs[next]); d < minDist[i] {
+				minDist[i] = d
+			}
+		}
+	}
+	out := make([]Swatch, 0, n)
+	for i, s := range swatches {
+		if chosen[i] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// padSwatches 颜色不足时，依次为已有颜色补充更亮/更暗的变体
+func padSwatches(swatches []Swatch, n int) []Swatch {
+	out := append([]Swatch{}, swatches...)
+	seen := make(map[string]bool)
+	for _, s := range swatches {
+		seen[s.Color] = true
+	}
+	for step := 1; len(out) < n && step <= 4; step++ {
+		for _, s := range swatches {
+			if len(out) >= n {
+				break
+			}
+			lch := colorutil.ToOKLCH(colorutil.MustParseHex(s.Color))
+			delta, label := 0.15*float64(step), "light"
+			if lch.L > 0.6 {
+				delta, label = -delta, "dark"
+			}
+			variant := colorutil.OKLCH{L: lch.L + delta, C: lch.C, H: lch.H}.RGB().Hex()
+			if seen[variant] {
+				continue
+			}
+			seen[variant] = true
+			out = append(out, Swatch{Name: fmt.Sprintf("%s %s %d", s.Name, label, step), Color: variant})
+		}
+	}
+	return out
+}
+
+func okDistance(a, b colorutil.OKLab) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return dl*dl + da*da + db*db
+}
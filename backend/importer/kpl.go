@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"ai-color-palette/colorutil"
+)
+
+// parseKPL 解析 Krita 色板（zip 包中的 colorset.xml），分组内的颜色同样读取
+func parseKPL(data []byte) (string, []Swatch, error) {
+	content, err := readZipFile(data, "colorset.xml")
+	if err != nil {
+		return "", nil, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	var name, entryName string
+	var swatches []Swatch
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("decode colorset.xml: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		attrs := make(map[string]string)
+		for _, attr := range start.Attr {
+			attrs[attr.Name.Local] = attr.Value
+		}
+		switch start.Name.Local {
+		case "ColorSet", "Colorset":
+			name = attrs["name"]
+		case "ColorSetEntry":
+			entryName = attrs["name"]
+		case "RGB", "sRGB":
+			c := colorutil.RGB{R: kplChannel(attrs["r"]), G: kplChannel(attrs["g"]), B: kplChannel(attrs["b"])}
+			swatches = append(swatches, Swatch{Name: entryName, Color: c.Hex()})
+		case "Lab":
+			l, _ := strconv.ParseFloat(attrs["L"], 64)
+			a, _ := strconv.ParseFloat(attrs["a"], 64)
+			b, _ := strconv.ParseFloat(attrs["b"], 64)
+			swatches = append(swatches, Swatch{Name: entryName, Color: colorutil.Lab{L: l, A: a, B: b}.RGB().Hex()})
+		case "CMYK":
+			c, _ := strconv.ParseFloat(attrs["c"], 64)
+			m, _ := strconv.ParseFloat(attrs["m"], 64)
+			y, _ := strconv.ParseFloat(attrs["y"], 64)
+			k, _ := strconv.ParseFloat(attrs["k"], 64)
+			swatches = append(swatches, Swatch{Name: entryName, Color: colorutil.FromCMYK(c, m, y, k).Hex()})
+		case "Gray":
+			g := kplChannel(attrs["g"])
+			swatches = append(swatches, Swatch{Name: entryName, Color: colorutil.RGB{R: g, G: g, B: g}.Hex()})
+		}
+	}
+	return name, swatches, nil
+}
+
+// kplChannel Krita 以 0-1 浮点存储通道值
+func kplChannel(value string) uint8 {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return unitByte(v)
+}
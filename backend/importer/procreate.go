@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"ai-color-palette/colorutil"
+)
+
+type procreateSwatch struct {
+	Hue        float64 `json:"hue"`
+	Saturation float64 `json:"saturation"`
+	Brightness float64 `json:"brightness"`
+}
+
+// parseProcreate 解析 Procreate 色板（zip 包中的 Swatches.json，颜色为 HSB）
+func parseProcreate(data []byte) (string, []Swatch, error) {
+	content, err := readZipFile(data, "Swatches.json")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var palettes []struct {
+		Name     string             `json:"name"`
+		Swatches []*procreateSwatch `json:"swatches"`
+	}
+	if err := json.Unmarshal(content, &palettes); err != nil {
+		// 部分导出为单个对象而非数组
+		var single struct {
+			Name     string             `json:"name"`
+			Swatches []*procreateSwatch `json:"swatches"`
+		}
+		if err2 := json.Unmarshal(content, &single); err2 != nil {
+			return "", nil, fmt.Errorf("decode Swatches.json: %w", err)
+		}
+		palettes = append(palettes, single)
+	}
+
+	var name string
+	var swatches []Swatch
+	for _, palette := range palettes {
+		if name == "" {
+			name = palette.Name
+		}
+		for _, s := range palette.Swatches {
+			// 空槽位为 null
+			if s == nil {
+				continue
+			}
+			c := colorutil.FromHSV(s.Hue, s.Saturation, s.Brightness)
+			swatches = append(swatches, Swatch{Color: c.Hex()})
+		}
+	}
+	return name, swatches, nil
+}
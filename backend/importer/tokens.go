@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// parseTokens 解析 DTCG 设计令牌 JSON，$type 可从父级分组继承
+func parseTokens(data []byte) (string, []Swatch, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return "", nil, fmt.Errorf("decode tokens: %w", err)
+	}
+	var swatches []Swatch
+	walkTokens(root, nil, "", &swatches)
+	name, _ := root["$description"].(string)
+	return name, swatches, nil
+}
+
+func walkTokens(node map[string]interface{}, path []string, inheritedType string, out *[]Swatch) {
+	tokenType := inheritedType
+	if t, ok := node["$type"].(string); ok {
+		tokenType = t
+	}
+
+	value, hasValue := node["$value"]
+	if !hasValue {
+		// 兼容 Style Dictionary 旧格式
+		value, hasValue = node["value"]
+		if t, ok := node["type"].(string); ok {
+			tokenType = t
+		}
+	}
+	if hasValue {
+		if tokenType != "" && tokenType != "color" {
+			return
+		}
+		if hex, ok := tokenColor(value); ok {
+			*out = append(*out, Swatch{Name: strings.Join(path, "-"), Color: hex})
+		}
+		return
+	}
+
+	// JSON 对象无序，按键排序保证结果稳定
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		if !strings.HasPrefix(key, "$") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if child, ok := node[key].(map[string]interface{}); ok {
+			walkTokens(child, append(append([]string{}, path...), key), tokenType, out)
+		}
+	}
+}
+
+// tokenColor 支持字符串颜色值与 DTCG 2025 的 {colorSpace, components, hex} 对象
+func tokenColor(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		c, ok := colorutil.ParseCSSColor(v)
+		return c.Hex(), ok
+	case map[string]interface{}:
+		if hex, ok := v["hex"].(string); ok {
+			c, ok := colorutil.ParseCSSColor(hex)
+			return c.Hex(), ok
+		}
+		space, _ := v["colorSpace"].(string)
+		components, ok := v["components"].([]interface{})
+		if !ok || len(components) < 3 || (space != "" && space != "srgb") {
+			return "", false
+		}
+		var channels [3]float64
+		for i := 0; i < 3; i++ {
+			f, ok := components[i].(float64)
+			if !ok {
+				return "", false
+			}
+			channels[i] = f
+		}
+		return colorutil.RGB{R: unitByte(channels[0]), G: unitByte(channels[1]), B: unitByte(channels[2])}.Hex(), true
+	}
+	return "", false
+}
@@ -0,0 +1,57 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// maxZipEntrySize 压缩包内单个文件的解压上限，防止压缩炸弹
+const maxZipEntrySize = 4 << 20
+
+// detectZip 通过压缩包内的文件区分 Krita 与 Procreate 色板
+func detectZip(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("open zip: %w", err)
+	}
+	for _, f := range zr.File {
+		switch strings.ToLower(path.Base(f.Name)) {
+		case "colorset.xml":
+			return "kpl", nil
+		case "swatches.json":
+			return "procreate", nil
+		}
+	}
+	return "", fmt.Errorf("zip archive is neither a Krita nor a Procreate palette")
+}
+
+// readZipFile 读取压缩包中指定名称的文件（不区分大小写）
+func readZipFile(data []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	for _, f := range zr.File {
+		if !strings.EqualFold(path.Base(f.Name), name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", name, err)
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(io.LimitReader(rc, maxZipEntrySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		if len(content) > maxZipEntrySize {
+			return nil, fmt.Errorf("%s is too large", name)
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("%s not found in archive", name)
+}
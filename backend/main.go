@@ -38,11 +38,35 @@ func main() {
 	config.AllowOrigins = []string{"http://localhost:5173", "http://localhost:3000", "*"}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization"}
+	config.ExposeHeaders = []string{"Content-Disposition", "X-Recolor-Mapping", "X-Wallpaper-Seed"}
 	router.Use(cors.New(config))
 	router.GET("/api/health", handler.HealthHandler)
+	router.GET("/api/capabilities", handler.CapabilitiesHandler)
+	router.GET("/api/admin/usage", handler.AdminAuth(), handler.UsageHandler)
+	router.GET("/api/admin/cache", handler.AdminAuth(), handler.CacheHandler)
+	router.GET("/metrics", handler.AdminAuth(), handler.MetricsHandler)
 	router.POST("/api/generate-palette", handler.GeneratePaletteHandler)
 	router.POST("/api/refine-palette", handler.RefinePaletteHandler)
 	router.POST("/api/regenerate-color", handler.RegenerateSingleColorHandler)
+	router.POST("/api/explain-palette", handler.ExplainPaletteHandler)
+	router.GET("/api/export/formats", handler.ExportFormatsHandler)
+	router.POST("/api/export", handler.ExportHandler)
+	router.POST("/api/terminal-theme", handler.TerminalThemeHandler)
+	router.POST("/api/ui-theme", handler.SemanticThemeHandler)
+	router.POST("/api/score", handler.ScoreHandler)
+	router.POST("/api/harmony", handler.HarmonyHandler)
+	router.POST("/api/harmonize", handler.HarmonizeHandler)
+	router.POST("/api/scales", handler.ScalesHandler)
+	router.POST("/api/gradient", handler.GradientHandler)
+	router.GET("/api/color/:hex", handler.ColorInfoHandler)
+	router.POST("/api/import", handler.ImportHandler)
+	router.POST("/api/extract-colors", handler.ExtractColorsHandler)
+	router.POST("/api/palette-from-image", handler.ImagePaletteHandler)
+	router.POST("/api/recolor", handler.RecolorHandler)
+	router.POST("/api/preview", handler.PreviewHandler)
+	router.POST("/api/wallpaper", handler.WallpaperHandler)
+	router.GET("/api/p/:id", handler.GetPaletteHandler)
+	router.GET("/api/p/:id/bundle.zip", handler.BundleHandler)
 	log.Println("[INFO] GIN Server ready")
 	log.Println("[INFO] GIN Server starting on :5208")
 	if err := router.Run(":5208"); err != nil {
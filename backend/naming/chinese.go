@@ -0,0 +1,60 @@
+package naming
+
+// chineseColors 中国传统色，按色系分组
+var chineseColors = []entry{
+	// 红
+	{"朱砂", "#FF461F"}, {"火红", "#FF2D51"}, {"朱膘", "#F36838"}, {"妃色", "#ED5736"},
+	{"洋红", "#FF4777"}, {"品红", "#F00056"}, {"粉红", "#FFB3A7"}, {"桃红", "#F47983"},
+	{"海棠红", "#DB5A6B"}, {"樱桃色", "#C93756"}, {"酡颜", "#F9906F"}, {"银红", "#F05654"},
+	{"大红", "#FF2121"}, {"石榴红", "#F20C00"}, {"绛紫", "#8C4356"}, {"绯红", "#C83C23"},
+	{"胭脂", "#9D2933"}, {"朱红", "#FF4C00"}, {"丹", "#FF4E20"}, {"彤", "#F35336"},
+	{"酡红", "#DC3023"}, {"炎", "#FF3300"}, {"茜色", "#CB3A56"}, {"绾", "#A98175"},
+	{"檀", "#B36D61"}, {"嫣红", "#EF7A82"}, {"枣红", "#C32136"}, {"殷红", "#BE002F"},
+	{"赫赤", "#C91F37"}, {"银朱", "#BF242A"}, {"赤", "#C3272B"}, {"绛", "#A61B29"},
+	{"栗色", "#60281E"}, {"玄色", "#622A1D"}, {"珊瑚", "#F08A5D"}, {"水红", "#F3D3E7"},
+
+	// 黄与橙
+	{"鹅黄", "#FFF143"}, {"鸭黄", "#FAFF72"}, {"樱草色", "#EAFF56"}, {"杏黄", "#FFA631"},
+	{"杏红", "#FF8C31"}, {"橘黄", "#FF8936"}, {"橙黄", "#FFA400"}, {"橘红", "#FF7500"},
+	{"姜黄", "#FFC773"}, {"缃色", "#F0C239"}, {"橙色", "#FA8C35"}, {"茶色", "#B35C44"},
+	{"驼色", "#A88462"}, {"昏黄", "#C89B40"}, {"棕色", "#B25D25"}, {"棕绿", "#827100"},
+	{"棕黑", "#7C4B00"}, {"棕红", "#9B4400"}, {"棕黄", "#AE7000"}, {"赭", "#9C5333"},
+	{"赭色", "#955539"}, {"琥珀", "#CA6924"}, {"褐色", "#6E511E"}, {"枯黄", "#D3B17D"},
+	{"黄栌", "#E29C45"}, {"秋色", "#896C39"}, {"秋香色", "#D9B611"}, {"雌黄", "#FFC64B"},
+	{"明黄", "#FFF200"}, {"金色", "#EACD76"}, {"乌金", "#A78E44"}, {"土黄", "#C7A252"},
+	{"藤黄", "#FFB61E"}, {"米色", "#EEDEB0"}, {"黄白", "#FFF8DC"},
+
+	// 绿
+	{"嫩绿", "#BDDD22"}, {"柳黄", "#C9DD22"}, {"柳绿", "#AFDD22"}, {"竹青", "#789262"},
+	{"葱黄", "#A3D900"}, {"葱绿", "#9ED900"}, {"葱青", "#0EB83A"}, {"葱倩", "#0EB840"},
+	{"青葱", "#0AA344"}, {"油绿", "#00BC12"}, {"绿沈", "#0C8918"}, {"碧色", "#1BD1A5"},
+	{"碧绿", "#2ADD9C"}, {"青碧", "#48C0A3"}, {"翡翠色", "#3DE1AD"}, {"草绿", "#40DE5A"},
+	{"青色", "#00E09E"}, {"青翠", "#00E079"}, {"青白", "#C0EBD7"}, {"鸭卵青", "#E0EEE8"},
+	{"蟹壳青", "#BBCDC5"}, {"鸦青", "#424C50"}, {"绿色", "#00E500"}, {"豆绿", "#9ED048"},
+	{"豆青", "#96CE54"}, {"石青", "#7BCFA6"}, {"玉色", "#2EDFA3"}, {"缥", "#7FECAD"},
+	{"艾绿", "#A4E2C6"}, {"松柏绿", "#21A675"}, {"松花绿", "#057748"}, {"松花色", "#BCE672"},
+	{"铜绿", "#549688"}, {"黛绿", "#426666"}, {"墨绿", "#2F4F3F"}, {"苍绿", "#223E36"},
+
+	// 蓝
+	{"蓝", "#44CEF6"}, {"靛青", "#177CB0"}, {"靛蓝", "#065279"}, {"碧蓝", "#3EEDE7"},
+	{"蔚蓝", "#70F3FF"}, {"宝蓝", "#4B5CC4"}, {"蓝灰色", "#A1AFC9"}, {"藏青", "#2E4E7E"},
+	{"藏蓝", "#3B2E7E"}, {"黛", "#4A4266"}, {"黛蓝", "#425066"}, {"绀青", "#003371"},
+	{"群青", "#4C8DAE"}, {"天青", "#5C9DC0"}, {"湖蓝", "#30A9DE"}, {"孔雀蓝", "#0EB0C9"},
+	{"钴蓝", "#1661AB"}, {"景泰蓝", "#2775B6"}, {"瓷蓝", "#6AA8CF"}, {"月白", "#D6ECF0"},
+	{"水色", "#88ADA6"}, {"水蓝", "#D2F0F4"}, {"晴山", "#A3BBDB"}, {"石蓝", "#2E59A7"},
+
+	// 紫
+	{"黛紫", "#574266"}, {"紫色", "#8D4BBB"}, {"紫酱", "#815463"}, {"酱紫", "#815476"},
+	{"紫檀", "#4C221B"}, {"紫棠", "#56004F"}, {"青莲", "#801DAE"}, {"雪青", "#B0A4E3"},
+	{"丁香色", "#CCA4E3"}, {"藕色", "#EDD1D8"}, {"藕荷色", "#E4C6D0"}, {"黝", "#6B6882"},
+	{"乌色", "#725E82"}, {"玄青", "#3D3B4F"}, {"暮山紫", "#A4ABD6"}, {"紫藤", "#8B2671"},
+
+	// 白、灰与黑
+	{"精白", "#FFFFFF"}, {"银白", "#E9E7EF"}, {"铅白", "#F0F0F4"}, {"霜色", "#E9F1F6"},
+	{"雪白", "#F0FCFF"}, {"莹白", "#E3F9FD"}, {"象牙白", "#FFFBF0"}, {"缟", "#F2ECDE"},
+	{"鱼肚白", "#FCEFE8"}, {"白粉", "#FFF2DF"}, {"荼白", "#F3F9F1"}, {"素", "#E0F0E9"},
+	{"花白", "#C2CCD0"}, {"老银", "#BACAC6"}, {"灰色", "#808080"}, {"苍色", "#75878A"},
+	{"墨色", "#50616D"}, {"黎", "#75664D"}, {"黧", "#5D513C"}, {"黝黑", "#665757"},
+	{"缁色", "#493131"}, {"乌黑", "#392F41"}, {"煤黑", "#312520"}, {"漆黑", "#161823"},
+	{"黑色", "#000000"}, {"墨灰", "#758A99"}, {"烟灰", "#8A8A8A"}, {"鼠灰", "#9E9E9E"},
+}
@@ -0,0 +1,72 @@
+package naming
+
+// englishColors 常用的英文描述性色名，覆盖设计与印刷中常见的叫法
+var englishColors = []entry{
+	{"White", "#FFFFFF"}, {"Snow", "#FFFAFA"}, {"Off White", "#FAF9F6"}, {"Ivory", "#FFFFF0"},
+	{"Cream", "#FFFDD0"}, {"Eggshell", "#F0EAD6"}, {"Bone", "#E3DAC9"}, {"Linen", "#FAF0E6"},
+	{"Alabaster", "#EDEADE"}, {"Pearl", "#EAE0C8"}, {"Parchment", "#F1E9D2"}, {"Vanilla", "#F3E5AB"},
+	{"Champagne", "#F7E7CE"}, {"Beige", "#F5F5DC"}, {"Sand", "#C2B280"}, {"Khaki", "#C3B091"},
+	{"Oatmeal", "#D8CAB2"}, {"Wheat", "#F5DEB3"}, {"Buff", "#F0DC82"},
+	{"Taupe", "#483C32"}, {"Greige", "#B5ADA0"}, {"Mushroom", "#A89F91"}, {"Stone", "#928E85"},
+
+	{"Light Gray", "#D3D3D3"}, {"Silver", "#C0C0C0"}, {"Ash Gray", "#B2BEB5"}, {"Cool Gray", "#8C92AC"},
+	{"Warm Gray", "#9E968D"}, {"Gray", "#808080"}, {"Pewter", "#8E9294"}, {"Slate Gray", "#708090"},
+	{"Steel Gray", "#71797E"}, {"Dim Gray", "#696969"}, {"Graphite", "#41424C"}, {"Charcoal", "#36454F"},
+	{"Gunmetal", "#2A3439"}, {"Onyx", "#353839"}, {"Jet", "#343434"}, {"Ebony", "#555D50"},
+	{"Off Black", "#1B1B1B"}, {"Black", "#000000"}, {"Licorice", "#1A1110"}, {"Ink", "#252A34"},
+
+	{"Blush", "#DE5D83"}, {"Baby Pink", "#F4C2C2"}, {"Pale Pink", "#FADADD"}, {"Pink", "#FFC0CB"},
+	{"Dusty Rose", "#C9A9A6"}, {"Rose Quartz", "#F7CAC9"}, {"Millennial Pink", "#F3CFC6"}, {"Salmon", "#FA8072"},
+	{"Coral", "#FF7F50"}, {"Peach", "#FFE5B4"}, {"Apricot", "#FBCEB1"}, {"Hot Pink", "#FF69B4"},
+	{"Bubblegum", "#FFC1CC"}, {"Flamingo", "#FC8EAC"}, {"Rose", "#FF007F"}, {"Magenta", "#FF00FF"},
+	{"Fuchsia", "#C154C1"}, {"Raspberry", "#E30B5C"}, {"Cerise", "#DE3163"}, {"Rose Gold", "#B76E79"},
+	{"Mauve", "#E0B0FF"}, {"Old Rose", "#C08081"}, {"Watermelon", "#FC6C85"}, {"Punch", "#EC5578"},
+
+	{"Red", "#FF0000"}, {"Scarlet", "#FF2400"}, {"Cherry Red", "#D2042D"}, {"Crimson", "#DC143C"},
+	{"Ruby", "#E0115F"}, {"Vermilion", "#E34234"}, {"Tomato", "#FF6347"}, {"Brick Red", "#CB4154"},
+	{"Carmine", "#960018"}, {"Cardinal", "#C41E3A"}, {"Candy Apple", "#FF0800"}, {"Fire Engine Red", "#CE2029"},
+	{"Burgundy", "#800020"}, {"Maroon", "#800000"}, {"Wine", "#722F37"}, {"Oxblood", "#4A0000"},
+	{"Garnet", "#733635"}, {"Merlot", "#730039"}, {"Claret", "#7F1734"}, {"Mahogany", "#C04000"},
+
+	{"Terracotta", "#E2725B"}, {"Rust", "#B7410E"}, {"Burnt Sienna", "#E97451"}, {"Burnt Orange", "#CC5500"},
+	{"Persimmon", "#EC5800"}, {"Orange", "#FFA500"}, {"Tangerine", "#F28500"}, {"Pumpkin", "#FF7518"},
+	{"Carrot", "#ED9121"}, {"Mango", "#FF8243"}, {"Cantaloupe", "#FFA62F"}, {"Papaya", "#FFEFD5"},
+	{"Copper", "#B87333"}, {"Bronze", "#CD7F32"}, {"Ginger", "#B06500"}, {"Cinnamon", "#D2691E"},
+	{"Amber", "#FFBF00"}, {"Honey", "#EBA937"}, {"Marigold", "#EAA221"}, {"Saffron", "#F4C430"},
+
+	{"Yellow", "#FFFF00"}, {"Lemon", "#FFF700"}, {"Canary", "#FFFF99"}, {"Butter", "#FFF1B5"},
+	{"Pale Yellow", "#FFFFBF"}, {"Mustard", "#FFDB58"}, {"Goldenrod", "#DAA520"}, {"Gold", "#FFD700"},
+	{"Ochre", "#CC7722"}, {"Dijon", "#C49102"}, {"Sunflower", "#FFDA03"}, {"Daffodil", "#FFFF31"},
+	{"Chartreuse", "#DFFF00"}, {"Lime", "#BFFF00"}, {"Pear", "#D1E231"}, {"Citron", "#9FA91F"},
+
+	{"Brown", "#964B00"}, {"Chocolate", "#7B3F00"}, {"Coffee", "#6F4E37"}, {"Espresso", "#4B3621"},
+	{"Mocha", "#967969"}, {"Latte", "#C5A582"}, {"Caramel", "#C68E17"}, {"Toffee", "#A9714B"},
+	{"Chestnut", "#954535"}, {"Walnut", "#773F1A"}, {"Umber", "#635147"}, {"Burnt Umber", "#8A3324"},
+	{"Sepia", "#704214"}, {"Camel", "#C19A6B"}, {"Tan", "#D2B48C"}, {"Fawn", "#E5AA70"},
+	{"Cocoa", "#875F42"}, {"Hazelnut", "#A67B5B"}, {"Russet", "#80461B"}, {"Bark", "#5C4033"},
+
+	{"Green", "#008000"}, {"Kelly Green", "#4CBB17"}, {"Grass Green", "#7CFC00"}, {"Emerald", "#50C878"},
+	{"Jade", "#00A86B"}, {"Malachite", "#0BDA51"}, {"Shamrock", "#009E60"}, {"Clover", "#3EA055"},
+	{"Forest Green", "#228B22"}, {"Hunter Green", "#355E3B"}, {"Bottle Green", "#006A4E"}, {"Pine", "#01796F"},
+	{"Olive", "#808000"}, {"Olive Drab", "#6B8E23"}, {"Army Green", "#4B5320"}, {"Moss", "#8A9A5B"},
+	{"Sage", "#BCB88A"}, {"Fern", "#4F7942"}, {"Avocado", "#568203"}, {"Pistachio", "#93C572"},
+	{"Mint", "#3EB489"}, {"Mint Cream", "#F5FFFA"}, {"Celadon", "#ACE1AF"}, {"Seafoam", "#9FE2BF"},
+	{"Pale Green", "#98FB98"}, {"Eucalyptus", "#44D7A8"}, {"Juniper", "#6D9292"}, {"Spruce", "#0A5F38"},
+
+	{"Teal", "#008080"}, {"Dark Teal", "#014D4E"}, {"Aqua", "#00FFFF"}, {"Turquoise", "#40E0D0"},
+	{"Aquamarine", "#7FFFD4"}, {"Cyan", "#00B7EB"}, {"Lagoon", "#017987"}, {"Peacock", "#33A1C9"},
+	{"Petrol", "#005F6A"}, {"Verdigris", "#43B3AE"}, {"Tiffany Blue", "#0ABAB5"}, {"Duck Egg", "#C3E4E8"},
+
+	{"Blue", "#0000FF"}, {"Sky Blue", "#87CEEB"}, {"Baby Blue", "#89CFF0"}, {"Powder Blue", "#B0E0E6"},
+	{"Ice Blue", "#DDF3F5"}, {"Light Blue", "#ADD8E6"}, {"Cornflower", "#6495ED"}, {"Periwinkle", "#CCCCFF"},
+	{"Cerulean", "#007BA7"}, {"Azure", "#007FFF"}, {"Denim", "#1560BD"}, {"Cobalt", "#0047AB"},
+	{"Sapphire", "#0F52BA"}, {"Royal Blue", "#4169E1"}, {"Ultramarine", "#120A8F"}, {"Steel Blue", "#4682B4"},
+	{"Dusty Blue", "#6C8EBF"}, {"Slate Blue", "#6A5ACD"}, {"Navy", "#000080"}, {"Midnight Blue", "#191970"},
+	{"Oxford Blue", "#002147"}, {"Prussian Blue", "#003153"}, {"Indigo", "#4B0082"}, {"Ink Blue", "#1F3A5F"},
+
+	{"Lavender", "#B57EDC"}, {"Pale Lavender", "#E6E6FA"}, {"Lilac", "#C8A2C8"}, {"Wisteria", "#C9A0DC"},
+	{"Orchid", "#DA70D6"}, {"Thistle", "#D8BFD8"}, {"Heather", "#B7A9C9"}, {"Amethyst", "#9966CC"},
+	{"Violet", "#8F00FF"}, {"Purple", "#800080"}, {"Grape", "#6F2DA8"}, {"Plum", "#8E4585"},
+	{"Eggplant", "#614051"}, {"Aubergine", "#3D0734"}, {"Mulberry", "#C54B8C"}, {"Byzantium", "#702963"},
+	{"Boysenberry", "#873260"}, {"Royal Purple", "#7851A9"}, {"Iris", "#5A4FCF"}, {"Dusty Purple", "#825F87"},
+}
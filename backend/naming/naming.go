@@ -0,0 +1,121 @@
+// Package naming 以 CIEDE2000 在内置色名表中查找最接近的颜色名称
+package naming
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"ai-color-palette/colorutil"
+)
+
+// 内置色名表
+const (
+	DatasetCSS     = "css"
+	DatasetEnglish = "english"
+	DatasetChinese = "chinese"
+)
+
+// Datasets 支持的色名表
+var Datasets = []string{DatasetCSS, DatasetEnglish, DatasetChinese}
+
+type entry struct {
+	name string
+	hex  string
+}
+
+type namedColor struct {
+	name string
+	hex  string
+	lab  colorutil.Lab
+}
+
+var (
+	loadOnce sync.Once
+	tables   map[string][]namedColor
+)
+
+// load 首次使用时预先计算各色名表的 Lab 值；CSS 色名按字母排序，保证同色别名（gray/grey）结果稳定
+func load() {
+	loadOnce.Do(func() {
+		css := make([]entry, 0, len(colorutil.CSSNamedColors))
+		for name, hex := range colorutil.CSSNamedColors {
+			css = append(css, entry{name, hex})
+		}
+		sort.Slice(css, func(i, j int) bool { return css[i].name < css[j].name })
+
+		tables = map[string][]namedColor{
+			DatasetCSS:     prepare(css),
+			DatasetEnglish: prepare(englishColors),
+			DatasetChinese: prepare(chineseColors),
+		}
+	})
+}
+
+func prepare(entries []entry) []namedColor {
+	out := make([]namedColor, len(entries))
+	for i, e := range entries {
+		c := colorutil.MustParseHex(e.hex)
+		out[i] = namedColor{name: e.name, hex: c.Hex(), lab: colorutil.ToLab(c)}
+	}
+	return out
+}
+
+// Match 最接近的命名颜色
+type Match struct {
+	Name    string  `json:"name"`
+	Hex     string  `json:"hex"`
+	DeltaE  float64 `json:"delta_e"`
+	Dataset string  `json:"dataset"`
+}
+
+// Names 一个颜色在各色名表中的最近匹配
+type Names struct {
+	CSS     Match `json:"css"`
+	English Match `json:"english"`
+	Chinese Match `json:"chinese"`
+}
+
+// Nearest 在指定色名表中查找 ΔE2000 最小的颜色
+func Nearest(c colorutil.RGB, dataset string) (Match, error) {
+	load()
+	table, ok := tables[dataset]
+	if !ok {
+		return Match{}, fmt.Errorf("unknown dataset: %s", dataset)
+	}
+	lab := colorutil.ToLab(c)
+	best, bestDist := 0, math.Inf(1)
+	for i, nc := range table {
+		if d := colorutil.DeltaE2000(lab, nc.lab); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return Match{
+		Name:    table[best].name,
+		Hex:     table[best].hex,
+		DeltaE:  math.Round(bestDist*100) / 100,
+		Dataset: dataset,
+	}, nil
+}
+
+// Lookup 返回颜色在全部色名表中的最近匹配
+func Lookup(c colorutil.RGB) Names {
+	css, _ := Nearest(c, DatasetCSS)
+	english, _ := Nearest(c, DatasetEnglish)
+	chinese, _ := Nearest(c, DatasetChinese)
+	return Names{CSS: css, English: english, Chinese: chinese}
+}
+
+// LookupAll 为一组 HEX 颜色查找名称，无法解析的颜色返回错误
+func LookupAll(colors []string) ([]Names, error) {
+	names := make([]Names, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = Lookup(c)
+	}
+	return names, nil
+}
@@ -0,0 +1,143 @@
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"sort"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/theme"
+)
+
+// Roles 预览中各界面元素使用的颜色
+type Roles struct {
+	Background string `json:"background"`
+	Surface    string `json:"surface"`
+	Primary    string `json:"primary"`
+	Secondary  string `json:"secondary"`
+	Accent     string `json:"accent"`
+	Text       string `json:"text"`
+	MutedText  string `json:"muted_text"`
+	OnPrimary  string `json:"on_primary"`
+	OnAccent   string `json:"on_accent"`
+	Border     string `json:"border"`
+}
+
+// RoleNames 可由调用方指定的角色
+var RoleNames = []string{"background", "surface", "primary", "secondary", "accent", "text"}
+
+type layout func(r Roles) *Scene
+
+var templates = map[string]layout{
+	"landing":   landingLayout,
+	"dashboard": dashboardLayout,
+	"mobile":    mobileLayout,
+}
+
+// Templates 返回内置的版式模板
+func Templates() []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveRoles 根据配色与可选的角色指定（角色名 -> 颜色下标）推导全部界面颜色，
+// 未指定的角色自动分配，文字颜色保证对比度
+func ResolveRoles(colors []string, assignment map[string]int) (Roles, error) {
+	if len(colors) == 0 {
+		return Roles{}, fmt.Errorf("palette has no colors")
+	}
+	for i, color := range colors {
+		normalized, ok := colorutil.NormalizeHex(color)
+		if !ok {
+			return Roles{}, fmt.Errorf("invalid color: %s", color)
+		}
+		colors[i] = normalized
+	}
+
+	auto := theme.AssignRoles(colors, false)
+	r := Roles{
+		Background: auto.Surface,
+		Primary:    auto.Primary,
+		Secondary:  auto.Secondary,
+		Accent:     auto.Tertiary,
+	}
+	for role, index := range assignment {
+		if index < 0 || index >= len(colors) {
+			return Roles{}, fmt.Errorf("role %s: index %d out of range", role, index)
+		}
+		switch role {
+		case "background":
+			r.Background = colors[index]
+		case "surface":
+			r.Surface = colors[index]
+		case "primary":
+			r.Primary = colors[index]
+		case "secondary":
+			r.Secondary = colors[index]
+		case "accent":
+			r.Accent = colors[index]
+		case "text":
+			r.Text = colors[index]
+		default:
+			return Roles{}, fmt.Errorf("unknown role: %s", role)
+		}
+	}
+
+	bg := colorutil.MustParseHex(r.Background)
+	if r.Surface == "" {
+		r.Surface = colorutil.Mix(bg, colorutil.White, 0.6).Hex()
+		if colorutil.RelativeLuminance(bg) < 0.18 {
+			r.Surface = colorutil.Mix(bg, colorutil.White, 0.08).Hex()
+		}
+	}
+	if r.Text == "" {
+		r.Text = darkestOrBest(colors, bg)
+	}
+	text := colorutil.EnsureContrast(colorutil.MustParseHex(r.Text), bg, 4.5)
+	r.Text = text.Hex()
+	r.MutedText = colorutil.EnsureContrast(colorutil.Mix(text, bg, 0.35), bg, 3).Hex()
+	r.Border = colorutil.Mix(text, bg, 0.8).Hex()
+	r.OnPrimary = colorutil.BestTextColor(colorutil.MustParseHex(r.Primary)).Hex()
+	r.OnAccent = colorutil.BestTextColor(colorutil.MustParseHex(r.Accent)).Hex()
+	return r, nil
+}
+
+// darkestOrBest 选择配色中与背景对比度最高的颜色作为正文色
+func darkestOrBest(colors []string, bg colorutil.RGB) string {
+	best, ratio := colors[0], 0.0
+	for _, color := range colors {
+		if r := colorutil.ContrastRatio(colorutil.MustParseHex(color), bg); r > ratio {
+			best, ratio = color, r
+		}
+	}
+	return best
+}
+
+// Render 按模板渲染预览，format 为 svg 或 png
+func Render(template string, roles Roles, format string) ([]byte, string, error) {
+	build, ok := templates[template]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown template: %s", template)
+	}
+	return build(roles).Encode(format)
+}
+
+// Encode 按格式输出画面，format 为 svg（默认）或 png，同时返回 Content-Type
+func (s *Scene) Encode(format string) ([]byte, string, error) {
+	switch format {
+	case "", "svg":
+		return s.SVG(), "image/svg+xml", nil
+	case "png":
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, s.Rasterize()); err != nil {
+			return nil, "", fmt.Errorf("encode png: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+	return nil, "", fmt.Errorf("unsupported format: %s", format)
+}
@@ -0,0 +1,206 @@
+package preview
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"ai-color-palette/colorutil"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// Rasterize 将场景栅格化。图形按有向距离场抗锯齿，文字使用内置点阵字体（仅 ASCII）
+func (s *Scene) Rasterize() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, s.Width, s.Height))
+	for _, sh := range s.shapes {
+		switch sh.kind {
+		case shapeRect:
+			if sh.fill != "none" {
+				cover(img, sh.x, sh.y, sh.x+sh.w, sh.y+sh.h, func(px, py float64) float64 {
+					return roundedRectDistance(px, py, sh)
+				}, parseColor(sh.fill))
+			}
+			if sh.stroke != "" {
+				stroke := parseColor(sh.stroke)
+				cover(img, sh.x-sh.strokeWidth, sh.y-sh.strokeWidth, sh.x+sh.w+sh.strokeWidth, sh.y+sh.h+sh.strokeWidth, func(px, py float64) float64 {
+					return math.Abs(roundedRectDistance(px, py, sh)) - sh.strokeWidth/2
+				}, stroke)
+			}
+		case shapeCircle:
+			cover(img, sh.x-sh.radius, sh.y-sh.radius, sh.x+sh.radius, sh.y+sh.radius, func(px, py float64) float64 {
+				return math.Hypot(px-sh.x, py-sh.y) - sh.radius
+			}, parseColor(sh.fill))
+		case shapeLine:
+			stroke := parseColor(sh.stroke)
+			for i := 1; i < len(sh.points); i++ {
+				a, b := sh.points[i-1], sh.points[i]
+				pad := sh.strokeWidth
+				cover(img, math.Min(a[0], b[0])-pad, math.Min(a[1], b[1])-pad, math.Max(a[0], b[0])+pad, math.Max(a[1], b[1])+pad,
+					func(px, py float64) float64 {
+						return segmentDistance(px, py, a, b) - sh.strokeWidth/2
+					}, stroke)
+			}
+		case shapeText:
+			drawText(img, sh)
+		case shapePolygon:
+			fillPolygon(img, sh)
+		case shapeGlow:
+			c := parseColor(sh.fill)
+			cover(img, sh.x-sh.radius, sh.y-sh.radius, sh.x+sh.radius, sh.y+sh.radius, func(px, py float64) float64 {
+				// cover 以 0.5-d 作为覆盖率，这里把衰减曲线换算成等效距离
+				return 0.5 - glowAlpha(math.Hypot(px-sh.x, py-sh.y)/sh.radius)*sh.opacity
+			}, c)
+		}
+	}
+	return img
+}
+
+func parseColor(hex string) color.NRGBA {
+	c, err := colorutil.ParseHex(hex)
+	if err != nil {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: 255}
+}
+
+// cover 对包围盒内的像素按距离场计算覆盖率并混合颜色
+func cover(img *image.NRGBA, x0, y0, x1, y1 float64, dist func(px, py float64) float64, c color.NRGBA) {
+	bounds := img.Bounds()
+	minX, minY := int(math.Max(math.Floor(x0)-1, 0)), int(math.Max(math.Floor(y0)-1, 0))
+	maxX, maxY := int(math.Min(math.Ceil(x1)+1, float64(bounds.Max.X))), int(math.Min(math.Ceil(y1)+1, float64(bounds.Max.Y)))
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			alpha := 0.5 - dist(float64(x)+0.5, float64(y)+0.5)
+			if alpha <= 0 {
+				continue
+			}
+			blend(img, x, y, c, math.Min(alpha, 1))
+		}
+	}
+}
+
+func blend(img *image.NRGBA, x, y int, c color.NRGBA, alpha float64) {
+	dst := img.NRGBAAt(x, y)
+	if dst.A == 0 {
+		img.SetNRGBA(x, y, color.NRGBA{R: c.R, G: c.G, B: c.B, A: uint8(math.Round(alpha * 255))})
+		return
+	}
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*(1-alpha) + float64(b)*alpha))
+	}
+	outA := float64(dst.A)/255*(1-alpha) + alpha
+	img.SetNRGBA(x, y, color.NRGBA{R: mix(dst.R, c.R), G: mix(dst.G, c.G), B: mix(dst.B, c.B), A: uint8(math.Round(outA * 255))})
+}
+
+// glowAlpha 在 glowStops 之间线性插值，与 SVG 径向渐变的渲染结果一致
+func glowAlpha(t float64) float64 {
+	if t >= 1 {
+		return 0
+	}
+	for i := 1; i < len(glowStops); i++ {
+		if t <= glowStops[i][0] {
+			a, b := glowStops[i-1], glowStops[i]
+			return a[1] + (b[1]-a[1])*(t-a[0])/(b[0]-a[0])
+		}
+	}
+	return 0
+}
+
+// fillPolygon 用扫描线光栅器填充多边形；带描边的面片先沿质心方向外扩半个描边宽度
+func fillPolygon(img *image.NRGBA, sh shape) {
+	if len(sh.points) < 3 {
+		return
+	}
+	points := sh.points
+	if sh.strokeWidth > 0 {
+		var cx, cy float64
+		for _, p := range points {
+			cx, cy = cx+p[0], cy+p[1]
+		}
+		cx, cy = cx/float64(len(points)), cy/float64(len(points))
+		points = make([][2]float64, len(sh.points))
+		for i, p := range sh.points {
+			dx, dy := p[0]-cx, p[1]-cy
+			d := math.Hypot(dx, dy)
+			if d == 0 {
+				points[i] = p
+				continue
+			}
+			grow := sh.strokeWidth / 2
+			points[i] = [2]float64{p[0] + dx/d*grow, p[1] + dy/d*grow}
+		}
+	}
+
+	bounds := img.Bounds()
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	r.DrawOp = draw.Over
+	r.MoveTo(float32(points[0][0]), float32(points[0][1]))
+	for _, p := range points[1:] {
+		r.LineTo(float32(p[0]), float32(p[1]))
+	}
+	r.ClosePath()
+	c := parseColor(sh.fill)
+	c.A = uint8(math.Round(255 * math.Max(0, math.Min(1, sh.opacity))))
+	r.Draw(img, bounds, image.NewUniform(c), image.Point{})
+}
+
+func roundedRectDistance(px, py float64, sh shape) float64 {
+	cx, cy := sh.x+sh.w/2, sh.y+sh.h/2
+	r := math.Min(sh.radius, math.Min(sh.w, sh.h)/2)
+	qx := math.Abs(px-cx) - (sh.w/2 - r)
+	qy := math.Abs(py-cy) - (sh.h/2 - r)
+	outside := math.Hypot(math.Max(qx, 0), math.Max(qy, 0))
+	inside := math.Min(math.Max(qx, qy), 0)
+	return outside + inside - r
+}
+
+func segmentDistance(px, py float64, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	length := dx*dx + dy*dy
+	t := 0.0
+	if length > 0 {
+		t = math.Max(0, math.Min(1, ((px-a[0])*dx+(py-a[1])*dy)/length))
+	}
+	return math.Hypot(px-(a[0]+t*dx), py-(a[1]+t*dy))
+}
+
+// drawText 以点阵字体绘制文字，按字号整数倍放大；非 ASCII 字符以方块占位
+func drawText(img *image.NRGBA, sh shape) {
+	face := basicfont.Face7x13
+	scale := int(math.Max(1, math.Round(sh.size/13)))
+	runes := []rune(sh.text)
+	for i, r := range runes {
+		if r > 0x7E {
+			runes[i] = '#'
+		}
+	}
+	text := string(runes)
+
+	width := font.MeasureString(face, text).Ceil()
+	mask := image.NewAlpha(image.Rect(0, 0, width+1, 13))
+	d := &font.Drawer{Dst: mask, Src: image.Opaque, Face: face, Dot: fixed.P(0, 10)}
+	d.DrawString(text)
+
+	c := parseColor(sh.fill)
+	originX, originY := int(sh.x), int(sh.y)-10*scale
+	passes := 1
+	if sh.bold {
+		passes = 2
+	}
+	for y := 0; y < 13; y++ {
+		for x := 0; x <= width; x++ {
+			if mask.AlphaAt(x, y).A == 0 {
+				continue
+			}
+			for p := 0; p < passes; p++ {
+				rect := image.Rect(originX+x*scale+p, originY+y*scale, originX+(x+1)*scale+p, originY+(y+1)*scale)
+				draw.Draw(img, rect.Intersect(img.Bounds()), &image.Uniform{C: c}, image.Point{}, draw.Over)
+			}
+		}
+	}
+}
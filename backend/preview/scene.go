@@ -0,0 +1,147 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type shapeKind int
+
+const (
+	shapeRect shapeKind = iota
+	shapeCircle
+	shapeLine
+	shapeText
+	shapePolygon
+	shapeGlow
+)
+
+// shape 场景中的一个图元，同时用于输出 SVG 与栅格化
+type shape struct {
+	kind        shapeKind
+	x, y, w, h  float64
+	radius      float64
+	fill        string
+	stroke      string
+	strokeWidth float64
+	points      [][2]float64
+	text        string
+	size        float64
+	bold        bool
+	opacity     float64
+}
+
+// Scene 由图元组成的画面
+type Scene struct {
+	Width, Height int
+	shapes        []shape
+}
+
+func (s *Scene) rect(x, y, w, h, radius float64, fill string) {
+	s.shapes = append(s.shapes, shape{kind: shapeRect, x: x, y: y, w: w, h: h, radius: radius, fill: fill})
+}
+
+func (s *Scene) outlinedRect(x, y, w, h, radius float64, fill, stroke string) {
+	s.shapes = append(s.shapes, shape{kind: shapeRect, x: x, y: y, w: w, h: h, radius: radius, fill: fill, stroke: stroke, strokeWidth: 1.5})
+}
+
+func (s *Scene) circle(cx, cy, r float64, fill string) {
+	s.shapes = append(s.shapes, shape{kind: shapeCircle, x: cx, y: cy, radius: r, fill: fill})
+}
+
+func (s *Scene) line(points [][2]float64, stroke string, width float64) {
+	s.shapes = append(s.shapes, shape{kind: shapeLine, points: points, stroke: stroke, strokeWidth: width})
+}
+
+func (s *Scene) text(x, y float64, text string, size float64, fill string, bold bool) {
+	s.shapes = append(s.shapes, shape{kind: shapeText, x: x, y: y, text: text, size: size, fill: fill, bold: bold})
+}
+
+// NewScene 创建空白画面，供其他包组合图元
+func NewScene(width, height int) *Scene {
+	return &Scene{Width: width, Height: height}
+}
+
+// Fill 以纯色铺满画面
+func (s *Scene) Fill(color string) {
+	s.rect(0, 0, float64(s.Width), float64(s.Height), 0, color)
+}
+
+// Polygon 填充多边形，opacity 为 0-1 的不透明度
+func (s *Scene) Polygon(points [][2]float64, fill string, opacity float64) {
+	s.shapes = append(s.shapes, shape{kind: shapePolygon, points: points, fill: fill, opacity: opacity})
+}
+
+// Facet 不透明的拼接面片：相邻面片共享边时向外扩出半像素，避免抗锯齿造成的接缝
+func (s *Scene) Facet(points [][2]float64, fill string) {
+	s.shapes = append(s.shapes, shape{kind: shapePolygon, points: points, fill: fill, opacity: 1, stroke: fill, strokeWidth: 1})
+}
+
+// Glow 以圆心向外平滑衰减至透明的光斑，叠加多个即可得到网格渐变效果
+func (s *Scene) Glow(cx, cy, r float64, fill string, opacity float64) {
+	s.shapes = append(s.shapes, shape{kind: shapeGlow, x: cx, y: cy, radius: r, fill: fill, opacity: opacity})
+}
+
+// glowStops 光斑的径向不透明度曲线（smoothstep 衰减），SVG 与栅格化共用
+var glowStops = [][2]float64{{0, 1}, {0.25, 0.844}, {0.5, 0.5}, {0.75, 0.156}, {1, 0}}
+
+// SVG 输出矢量图
+func (s *Scene) SVG() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", s.Width, s.Height, s.Width, s.Height)
+	glows := 0
+	for _, sh := range s.shapes {
+		switch sh.kind {
+		case shapeRect:
+			stroke := ""
+			if sh.stroke != "" {
+				stroke = fmt.Sprintf(" stroke=\"%s\" stroke-width=\"%g\"", sh.stroke, sh.strokeWidth)
+			}
+			fmt.Fprintf(&b, "  <rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" rx=\"%g\" fill=\"%s\"%s/>\n",
+				sh.x, sh.y, sh.w, sh.h, sh.radius, sh.fill, stroke)
+		case shapeCircle:
+			fmt.Fprintf(&b, "  <circle cx=\"%g\" cy=\"%g\" r=\"%g\" fill=\"%s\"/>\n", sh.x, sh.y, sh.radius, sh.fill)
+		case shapeLine:
+			points := make([]string, len(sh.points))
+			for i, p := range sh.points {
+				points[i] = fmt.Sprintf("%g,%g", p[0], p[1])
+			}
+			fmt.Fprintf(&b, "  <polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%g\" stroke-linecap=\"round\" stroke-linejoin=\"round\"/>\n",
+				strings.Join(points, " "), sh.stroke, sh.strokeWidth)
+		case shapeText:
+			weight := "400"
+			if sh.bold {
+				weight = "700"
+			}
+			var escaped bytes.Buffer
+			xml.EscapeText(&escaped, []byte(sh.text))
+			fmt.Fprintf(&b, "  <text x=\"%g\" y=\"%g\" font-family=\"Inter, 'PingFang SC', sans-serif\" font-size=\"%g\" font-weight=\"%s\" fill=\"%s\">%s</text>\n",
+				sh.x, sh.y, sh.size, weight, sh.fill, escaped.String())
+		case shapePolygon:
+			points := make([]string, len(sh.points))
+			for i, p := range sh.points {
+				points[i] = fmt.Sprintf("%.1f,%.1f", p[0], p[1])
+			}
+			extra := ""
+			if sh.opacity < 1 {
+				extra = fmt.Sprintf(" fill-opacity=\"%.3g\"", sh.opacity)
+			}
+			if sh.stroke != "" {
+				extra += fmt.Sprintf(" stroke=\"%s\" stroke-width=\"%g\" stroke-linejoin=\"round\"", sh.stroke, sh.strokeWidth)
+			}
+			fmt.Fprintf(&b, "  <polygon points=\"%s\" fill=\"%s\"%s/>\n", strings.Join(points, " "), sh.fill, extra)
+		case shapeGlow:
+			glows++
+			fmt.Fprintf(&b, "  <radialGradient id=\"glow%d\">", glows)
+			for _, stop := range glowStops {
+				fmt.Fprintf(&b, "<stop offset=\"%g\" stop-color=\"%s\" stop-opacity=\"%.3g\"/>", stop[0], sh.fill, stop[1]*sh.opacity)
+			}
+			b.WriteString("</radialGradient>\n")
+			fmt.Fprintf(&b, "  <circle cx=\"%.1f\" cy=\"%.1f\" r=\"%.1f\" fill=\"url(#glow%d)\"/>\n", sh.x, sh.y, sh.radius, glows)
+		}
+	}
+	b.WriteString("</svg>\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,166 @@
+package preview
+
+// chartValues 图表使用的固定示例数据
+var chartValues = []float64{0.35, 0.5, 0.42, 0.68, 0.6, 0.82, 0.74}
+
+// button 实心或描边按钮
+func (s *Scene) button(x, y, w float64, label, fill, text string, outline bool) {
+	if outline {
+		s.outlinedRect(x, y, w, 44, 10, "none", fill)
+		s.text(x+18, y+28, label, 15, fill, true)
+		return
+	}
+	s.rect(x, y, w, 44, 10, fill)
+	s.text(x+18, y+28, label, 15, text, true)
+}
+
+// bodyText 多行正文，第一行为标题
+func (s *Scene) bodyText(x, y float64, r Roles, title string, lines []string) {
+	s.text(x, y, title, 20, r.Text, true)
+	for i, line := range lines {
+		s.text(x, y+30+float64(i)*22, line, 14, r.MutedText, false)
+	}
+}
+
+// card 带标题、正文与标签的卡片
+func (s *Scene) card(x, y, w, h float64, r Roles) {
+	s.outlinedRect(x, y, w, h, 16, r.Surface, r.Border)
+	s.rect(x+20, y+20, w-40, h*0.38, 10, r.Secondary)
+	s.circle(x+w-50, y+20+h*0.19, h*0.1, r.Accent)
+	s.text(x+20, y+h*0.38+52, "Palette card", 18, r.Text, true)
+	s.text(x+20, y+h*0.38+76, "Colors in real context.", 14, r.MutedText, false)
+	s.rect(x+20, y+h-44, 72, 24, 12, r.Accent)
+	s.text(x+30, y+h-27, "NEW", 12, r.OnAccent, true)
+}
+
+// form 带输入框、复选框与提交按钮的表单
+func (s *Scene) form(x, y, w float64, r Roles) {
+	s.outlinedRect(x, y, w, 300, 16, r.Surface, r.Border)
+	s.text(x+24, y+40, "Sign up", 20, r.Text, true)
+	for i, label := range []string{"Email", "Password"} {
+		fy := y + 64 + float64(i)*76
+		s.text(x+24, fy+14, label, 13, r.MutedText, false)
+		s.outlinedRect(x+24, fy+22, w-48, 40, 8, r.Background, r.Border)
+	}
+	s.outlinedRect(x+24, y+222, 18, 18, 4, r.Primary, r.Primary)
+	s.text(x+52, y+236, "Remember me", 13, r.Text, false)
+	s.button(x+w-144, y+214, 120, "Submit", r.Primary, r.OnPrimary, false)
+}
+
+// chart 折线图与柱状图
+func (s *Scene) chart(x, y, w, h float64, r Roles) {
+	s.outlinedRect(x, y, w, h, 16, r.Surface, r.Border)
+	s.text(x+24, y+38, "Monthly growth", 18, r.Text, true)
+	top, bottom := y+60, y+h-30
+	for i := 0; i < 4; i++ {
+		gy := top + (bottom-top)*float64(i)/3
+		s.line([][2]float64{{x + 24, gy}, {x + w - 24, gy}}, r.Border, 1)
+	}
+	step := (w - 48) / float64(len(chartValues))
+	points := make([][2]float64, len(chartValues))
+	for i, v := range chartValues {
+		bx := x + 24 + step*float64(i)
+		barH := (bottom - top) * v * 0.7
+		s.rect(bx+step*0.2, bottom-barH, step*0.6, barH, 4, r.Secondary)
+		points[i] = [2]float64{bx + step/2, bottom - (bottom-top)*v}
+	}
+	s.line(points, r.Accent, 3)
+	for _, p := range points {
+		s.circle(p[0], p[1], 5, r.Primary)
+	}
+}
+
+// landingLayout 落地页：导航、主视觉、卡片、表单、图表与正文
+func landingLayout(r Roles) *Scene {
+	s := &Scene{Width: 1200, Height: 900}
+	s.rect(0, 0, 1200, 900, 0, r.Background)
+
+	s.circle(60, 40, 16, r.Primary)
+	s.text(88, 46, "PaletteFlow", 18, r.Text, true)
+	for i, item := range []string{"Product", "Pricing", "Docs"} {
+		s.text(760+float64(i)*100, 46, item, 15, r.MutedText, false)
+	}
+	s.button(1060, 18, 110, "Sign in", r.Accent, r.OnAccent, false)
+
+	s.text(60, 170, "Colors that", 52, r.Text, true)
+	s.text(60, 232, "flow together.", 52, r.Primary, true)
+	s.text(60, 280, "Generate harmonious palettes and preview them in a real UI.", 16, r.MutedText, false)
+	s.button(60, 310, 160, "Get started", r.Primary, r.OnPrimary, false)
+	s.button(236, 310, 150, "Learn more", r.Secondary, r.Text, true)
+
+	s.rect(660, 100, 480, 300, 24, r.Primary)
+	s.circle(1060, 170, 60, r.Accent)
+	s.rect(700, 290, 260, 18, 9, r.OnPrimary)
+	s.rect(700, 322, 180, 14, 7, r.Secondary)
+
+	s.card(60, 430, 340, 300, r)
+	s.form(430, 430, 340, r)
+	s.chart(800, 430, 340, 300, r)
+
+	s.bodyText(60, 790, r, "Why palettes matter", []string{
+		"Consistent color builds recognition and guides attention to what matters most.",
+		"Check contrast between text and background before shipping.",
+	})
+	return s
+}
+
+// dashboardLayout 后台：侧栏、统计卡片、图表、表单与正文
+func dashboardLayout(r Roles) *Scene {
+	s := &Scene{Width: 1280, Height: 820}
+	s.rect(0, 0, 1280, 820, 0, r.Background)
+	s.rect(0, 0, 240, 820, 0, r.Primary)
+	s.text(32, 56, "PaletteFlow", 20, r.OnPrimary, true)
+	for i, item := range []string{"Overview", "Palettes", "Exports", "Settings"} {
+		y := 110 + float64(i)*52
+		if i == 0 {
+			s.rect(16, y-28, 208, 42, 10, r.Accent)
+			s.text(32, y, item, 15, r.OnAccent, true)
+			continue
+		}
+		s.text(32, y, item, 15, r.OnPrimary, false)
+	}
+
+	s.text(280, 64, "Overview", 28, r.Text, true)
+	s.button(1110, 30, 130, "New palette", r.Accent, r.OnAccent, false)
+	for i, stat := range []struct{ label, value string }{{"Palettes", "128"}, {"Exports", "1,024"}, {"Contrast AA", "96%"}} {
+		x := 280 + float64(i)*324
+		s.outlinedRect(x, 100, 300, 110, 16, r.Surface, r.Border)
+		s.text(x+24, 140, stat.label, 14, r.MutedText, false)
+		s.text(x+24, 186, stat.value, 32, r.Text, true)
+		s.circle(x+256, 150, 18, []string{r.Primary, r.Secondary, r.Accent}[i])
+	}
+
+	s.chart(280, 240, 600, 320, r)
+	s.form(904, 240, 336, r)
+	s.card(904, 560, 336, 230, r)
+	s.bodyText(280, 620, r, "Recent activity", []string{
+		"Ocean breeze palette exported to Android and iOS.",
+		"Brand refresh palette refined: warmer accent color.",
+		"Contrast check passed for all text combinations.",
+	})
+	return s
+}
+
+// mobileLayout 移动端：单列主视觉、卡片、图表、表单与正文
+func mobileLayout(r Roles) *Scene {
+	s := &Scene{Width: 420, Height: 1500}
+	s.rect(0, 0, 420, 1500, 0, r.Background)
+	s.rect(0, 0, 420, 64, 0, r.Primary)
+	s.text(24, 40, "PaletteFlow", 18, r.OnPrimary, true)
+	s.circle(380, 32, 14, r.Accent)
+
+	s.text(24, 130, "Colors that", 34, r.Text, true)
+	s.text(24, 172, "flow together.", 34, r.Primary, true)
+	s.text(24, 206, "Preview palettes in a real UI.", 15, r.MutedText, false)
+	s.button(24, 230, 180, "Get started", r.Primary, r.OnPrimary, false)
+	s.button(216, 230, 180, "Learn more", r.Secondary, r.Text, true)
+
+	s.card(24, 300, 372, 300, r)
+	s.chart(24, 630, 372, 300, r)
+	s.form(24, 960, 372, r)
+	s.bodyText(24, 1310, r, "About", []string{
+		"Consistent color builds recognition.",
+		"Check contrast before shipping.",
+	})
+	return s
+}
@@ -0,0 +1,90 @@
+package quantize
+
+import (
+	"math"
+	"math/rand"
+
+	"ai-color-palette/colorutil"
+)
+
+const kMeansIterations = 20
+
+// kMeans 使用 k-means++ 初始化的 k-means 聚类，固定随机种子保证同一图片结果稳定
+func kMeans(samples []sample, k int) []Cluster {
+	if k > len(samples) {
+		k = len(samples)
+	}
+	rng := rand.New(rand.NewSource(1))
+	centers := []colorutil.OKLab{samples[rng.Intn(len(samples))].lab}
+	dist := make([]float64, len(samples))
+	for len(centers) < k {
+		total := 0.0
+		for i, s := range samples {
+			d := math.Inf(1)
+			for _, c := range centers {
+				d = math.Min(d, distance(s.lab, c))
+			}
+			dist[i] = d * d
+			total += dist[i]
+		}
+		if total == 0 {
+			break
+		}
+		target := rng.Float64() * total
+		for i, d := range dist {
+			target -= d
+			if target <= 0 {
+				centers = append(centers, samples[i].lab)
+				break
+			}
+		}
+	}
+
+	assign := make([]int, len(samples))
+	for iter := 0; iter < kMeansIterations; iter++ {
+		changed := false
+		for i, s := range samples {
+			best := 0
+			for j := 1; j < len(centers); j++ {
+				if distance(s.lab, centers[j]) < distance(s.lab, centers[best]) {
+					best = j
+				}
+			}
+			if assign[i] != best {
+				assign[i] = best
+				changed = true
+			}
+		}
+
+		sums := make([]colorutil.OKLab, len(centers))
+		counts := make([]int, len(centers))
+		for i, s := range samples {
+			j := assign[i]
+			sums[j].L += s.lab.L
+			sums[j].A += s.lab.A
+			sums[j].B += s.lab.B
+			counts[j]++
+		}
+		for j := range centers {
+			if counts[j] > 0 {
+				n := float64(counts[j])
+				centers[j] = colorutil.OKLab{L: sums[j].L / n, A: sums[j].A / n, B: sums[j].B / n}
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+	}
+
+	counts := make([]int, len(centers))
+	for _, j := range assign {
+		counts[j]++
+	}
+	clusters := make([]Cluster, 0, len(centers))
+	for j, c := range centers {
+		if counts[j] > 0 {
+			clusters = append(clusters, Cluster{lab: c, count: counts[j]})
+		}
+	}
+	return clusters
+}
@@ -0,0 +1,89 @@
+package quantize
+
+import (
+	"sort"
+
+	"ai-color-palette/colorutil"
+)
+
+type box struct {
+	samples []sample
+}
+
+// spread 返回跨度最大的通道（0=L,1=a,2=b）及其跨度
+func (b box) spread() (int, float64) {
+	lo := [3]float64{1e9, 1e9, 1e9}
+	hi := [3]float64{-1e9, -1e9, -1e9}
+	for _, s := range b.samples {
+		v := [3]float64{s.lab.L, s.lab.A, s.lab.B}
+		for i := range v {
+			if v[i] < lo[i] {
+				lo[i] = v[i]
+			}
+			if v[i] > hi[i] {
+				hi[i] = v[i]
+			}
+		}
+	}
+	axis := 0
+	for i := 1; i < 3; i++ {
+		if hi[i]-lo[i] > hi[axis]-lo[axis] {
+			axis = i
+		}
+	}
+	return axis, hi[axis] - lo[axis]
+}
+
+// medianCut 反复沿跨度最大的通道在中位数处切分颜色盒
+func medianCut(samples []sample, k int) []Cluster {
+	boxes := []box{{samples: samples}}
+	for len(boxes) < k {
+		target, axis, widest := -1, 0, 0.0
+		for i, b := range boxes {
+			if len(b.samples) < 2 {
+				continue
+			}
+			a, w := b.spread()
+			if target < 0 || w > widest {
+				target, axis, widest = i, a, w
+			}
+		}
+		if target < 0 || widest == 0 {
+			break
+		}
+
+		s := boxes[target].samples
+		sort.Slice(s, func(i, j int) bool {
+			return channel(s[i].lab, axis) < channel(s[j].lab, axis)
+		})
+		mid := len(s) / 2
+		boxes[target] = box{samples: s[:mid]}
+		boxes = append(boxes, box{samples: s[mid:]})
+	}
+
+	clusters := make([]Cluster, 0, len(boxes))
+	for _, b := range boxes {
+		var sum colorutil.OKLab
+		for _, s := range b.samples {
+			sum.L += s.lab.L
+			sum.A += s.lab.A
+			sum.B += s.lab.B
+		}
+		n := float64(len(b.samples))
+		clusters = append(clusters, Cluster{
+			lab:   colorutil.OKLab{L: sum.L / n, A: sum.A / n, B: sum.B / n},
+			count: len(b.samples),
+		})
+	}
+	return clusters
+}
+
+func channel(lab colorutil.OKLab, axis int) float64 {
+	switch axis {
+	case 1:
+		return lab.A
+	case 2:
+		return lab.B
+	}
+	return lab.L
+}
@@ -0,0 +1,149 @@
+package quantize
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	// maxSamples 参与聚类的最大像素数，大图按步长采样
+	maxSamples = 40000
+	// DefaultMinDistance 默认去重阈值（OKLab 欧氏距离）
+	DefaultMinDistance = 0.06
+)
+
+// Options 量化参数
+type Options struct {
+	Count       int     // 返回的颜色数量
+	Method      string  // kmeans 或 mediancut
+	Dedupe      bool    // 是否合并相近颜色
+	MinDistance float64 // 相近颜色的判定阈值
+	// ChromaWeight 在占比之外按彩度加权，使小面积的鲜艳色不被淹没（0 表示仅按占比排序）
+	ChromaWeight float64
+}
+
+// Cluster 量化得到的一种颜色及其像素占比
+type Cluster struct {
+	Color  string  `json:"color"`
+	Weight float64 `json:"weight"`
+	lab    colorutil.OKLab
+	count  int
+}
+
+// Lab 返回簇中心的 OKLab 坐标
+func (c Cluster) Lab() colorutil.OKLab {
+	return c.lab
+}
+
+type sample struct {
+	lab colorutil.OKLab
+}
+
+// Palette 在 OKLab 感知空间中对图片进行量化，按主导程度返回颜色
+func Palette(img image.Image, opts Options) ([]Cluster, error) {
+	if opts.Count <= 0 {
+		opts.Count = 5
+	}
+	if opts.MinDistance <= 0 {
+		opts.MinDistance = DefaultMinDistance
+	}
+	samples := collectSamples(img)
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("image has no opaque pixels")
+	}
+
+	// 多聚出一些簇，去重后仍能凑足数量
+	k := opts.Count
+	if opts.Dedupe {
+		k = opts.Count * 2
+	}
+	var clusters []Cluster
+	switch opts.Method {
+	case "mediancut":
+		clusters = medianCut(samples, k)
+	case "", "kmeans":
+		clusters = kMeans(samples, k)
+	default:
+		return nil, fmt.Errorf("unsupported method: %s", opts.Method)
+	}
+
+	if opts.Dedupe {
+		clusters = mergeSimilar(clusters, opts.MinDistance)
+	}
+	for i := range clusters {
+		clusters[i].Weight = float64(clusters[i].count) / float64(len(samples))
+		clusters[i].Color = clusters[i].lab.RGB().Hex()
+	}
+	score := func(c Cluster) float64 {
+		return c.Weight * (1 + opts.ChromaWeight*c.lab.LCH().C/0.2)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return score(clusters[i]) > score(clusters[j])
+	})
+	if len(clusters) > opts.Count {
+		clusters = clusters[:opts.Count]
+	}
+	for i := range clusters {
+		clusters[i].Weight = math.Round(clusters[i].Weight*10000) / 10000
+	}
+	return clusters, nil
+}
+
+func collectSamples(img image.Image) []sample {
+	bounds := img.Bounds()
+	total := bounds.Dx() * bounds.Dy()
+	step := 1
+	if total > maxSamples {
+		step = int(math.Ceil(math.Sqrt(float64(total) / maxSamples)))
+	}
+	samples := make([]sample, 0, total/(step*step)+1)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			r, g, b, a := img.At(x, y).RGBA()
+			// 忽略大部分透明的像素
+			if a < 0x8000 {
+				continue
+			}
+			// 去除预乘 alpha
+			c := colorutil.RGB{R: uint8(r * 0xFF / a), G: uint8(g * 0xFF / a), B: uint8(b * 0xFF / a)}
+			samples = append(samples, sample{lab: colorutil.ToOKLab(c)})
+		}
+	}
+	return samples
+}
+
+func distance(a, b colorutil.OKLab) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// mergeSimilar 将距离小于阈值的簇按像素数加权合并
+func mergeSimilar(clusters []Cluster, minDistance float64) []Cluster {
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].count > clusters[j].count })
+	var merged []Cluster
+	for _, c := range clusters {
+		absorbed := false
+		for i := range merged {
+			if distance(merged[i].lab, c.lab) < minDistance {
+				total := float64(merged[i].count + c.count)
+				wa, wb := float64(merged[i].count)/total, float64(c.count)/total
+				merged[i].lab = colorutil.OKLab{
+					L: merged[i].lab.L*wa + c.lab.L*wb,
+					A: merged[i].lab.A*wa + c.lab.A*wb,
+					B: merged[i].lab.B*wa + c.lab.B*wb,
+				}
+				merged[i].count += c.count
+				absorbed = true
+				break
+			}
+		}
+		if !absorbed {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
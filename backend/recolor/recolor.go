@@ -0,0 +1,240 @@
+package recolor
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/quantize"
+)
+
+const (
+	MatchLuminance = "luminance"
+	MatchOptimal   = "optimal"
+
+	// maxPixels 处理的最大像素数，避免超大图片占满内存
+	maxPixels = 16 << 20
+	// softness 软分配的距离尺度（OKLab），越大过渡越平滑
+	softness = 0.05
+)
+
+// Options 调色参数
+type Options struct {
+	Match string // luminance 或 optimal
+	// Strength 0-1，1 表示完全替换为目标配色
+	Strength float64
+}
+
+// Pair 源颜色与目标颜色的对应关系
+type Pair struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Weight float64 `json:"weight"`
+}
+
+// Apply 将图片量化为与目标配色同样数量的颜色，按匹配关系逐像素迁移到目标配色，
+// 保留原图像素相对于所属簇中心的明度与色度偏移，从而保持明暗结构
+func Apply(img image.Image, target []string, opts Options) (*image.NRGBA, []Pair, error) {
+	if len(target) == 0 {
+		return nil, nil, fmt.Errorf("target palette is empty")
+	}
+	bounds := img.Bounds()
+	if bounds.Dx()*bounds.Dy() > maxPixels {
+		return nil, nil, fmt.Errorf("image is too large (max %d pixels)", maxPixels)
+	}
+	if opts.Strength <= 0 || opts.Strength > 1 {
+		opts.Strength = 1
+	}
+
+	targets := make([]colorutil.OKLab, len(target))
+	for i, hex := range target {
+		c, err := colorutil.ParseHex(hex)
+		if err != nil {
+			return nil, nil, err
+		}
+		targets[i] = colorutil.ToOKLab(c)
+	}
+
+	clusters, err := quantize.Palette(img, quantize.Options{Count: len(targets)})
+	if err != nil {
+		return nil, nil, err
+	}
+	sources := make([]colorutil.OKLab, len(clusters))
+	for i, c := range clusters {
+		sources[i] = c.Lab()
+	}
+
+	var mapping []int
+	switch opts.Match {
+	case "", MatchLuminance:
+		mapping = matchByLuminance(sources, targets)
+	case MatchOptimal:
+		mapping = matchOptimal(sources, targets)
+	default:
+		return nil, nil, fmt.Errorf("unsupported match method: %s", opts.Match)
+	}
+
+	pairs := make([]Pair, len(sources))
+	for i := range sources {
+		pairs[i] = Pair{Source: clusters[i].Color, Target: target[mapping[i]], Weight: clusters[i].Weight}
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	weights := make([]float64, len(sources))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if px.A == 0 {
+				continue
+			}
+			lab := colorutil.ToOKLab(colorutil.RGB{R: px.R, G: px.G, B: px.B})
+
+			// 按到各簇中心的距离做软分配，避免簇边界出现色带
+			total := 0.0
+			for i, s := range sources {
+				d := distance(lab, s)
+				weights[i] = math.Exp(-(d * d) / (softness * softness))
+				total += weights[i]
+			}
+			var shifted colorutil.OKLab
+			if total < 1e-12 {
+				nearest := 0
+				for i, s := range sources {
+					if distance(lab, s) < distance(lab, sources[nearest]) {
+						nearest = i
+					}
+				}
+				weights[nearest], total = 1, 1
+			}
+			for i, s := range sources {
+				w := weights[i] / total
+				t := targets[mapping[i]]
+				shifted.L += w * (t.L - s.L)
+				shifted.A += w * (t.A - s.A)
+				shifted.B += w * (t.B - s.B)
+			}
+
+			result := colorutil.OKLab{
+				L: lab.L + opts.Strength*shifted.L,
+				A: lab.A + opts.Strength*shifted.A,
+				B: lab.B + opts.Strength*shifted.B,
+			}.LCH().RGB()
+			out.SetNRGBA(x-bounds.Min.X, y-bounds.Min.Y, color.NRGBA{R: result.R, G: result.G, B: result.B, A: px.A})
+		}
+	}
+	return out, pairs, nil
+}
+
+func distance(a, b colorutil.OKLab) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+// matchByLuminance 源与目标分别按明度排序后一一对应；数量不同时按比例映射
+func matchByLuminance(sources, targets []colorutil.OKLab) []int {
+	srcOrder := orderByLightness(sources)
+	dstOrder := orderByLightness(targets)
+	mapping := make([]int, len(sources))
+	for rank, i := range srcOrder {
+		j := rank
+		if len(sources) > 1 {
+			j = int(math.Round(float64(rank) * float64(len(targets)-1) / float64(len(sources)-1)))
+		}
+		mapping[i] = dstOrder[j]
+	}
+	return mapping
+}
+
+func orderByLightness(labs []colorutil.OKLab) []int {
+	order := make([]int, len(labs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return labs[order[a]].L < labs[order[b]].L })
+	return order
+}
+
+// matchOptimal 以 OKLab 距离为代价求最小总代价的一一指派（匈牙利算法）
+func matchOptimal(sources, targets []colorutil.OKLab) []int {
+	// 源颜色多于目标时，目标列重复使用以保证方阵可解
+	n := len(sources)
+	if len(targets) > n {
+		n = len(targets)
+	}
+	cost := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+		for j := range cost[i] {
+			if i < len(sources) {
+				cost[i][j] = distance(sources[i], targets[j%len(targets)])
+			}
+		}
+	}
+	assignment := hungarian(cost)
+	mapping := make([]int, len(sources))
+	for i := range sources {
+		mapping[i] = assignment[i] % len(targets)
+	}
+	return mapping
+}
+
+// hungarian 返回每一行指派到的列，使总代价最小
+func hungarian(cost [][]float64) []int {
+	n := len(cost)
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1)
+	way := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				cur := cost[i0-1][j-1] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	assignment := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if p[j] > 0 {
+			assignment[p[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
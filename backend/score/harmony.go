@@ -0,0 +1,17 @@
+package score
+
+import (
+	"math"
+
+	"ai-color-palette/colortheory"
+)
+
+// harmony 按 /api/harmony 的识别结果评分：彩色颜色离最接近方案锚点的最大偏差越小得分越高，
+// 全部为无彩色时视为完全和谐
+func harmony(colors []string) (float64, string, float64) {
+	d, err := colortheory.Detect(colors)
+	if err != nil || d.Scheme == colortheory.Neutral {
+		return 100, colortheory.Neutral, 0
+	}
+	return 100 * (1 - math.Min(1, d.Offset/maxHarmonyOffset)), d.Scheme, d.Offset
+}
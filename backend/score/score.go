@@ -0,0 +1,189 @@
+// Package score 从明度阶梯、色相和谐、可区分度、文字对比度、色盲可辨性与彩度平衡六个维度为配色打分
+package score
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"ai-color-palette/colorutil"
+)
+
+// weights 各维度在总分中的权重，合计为 1
+var weights = struct {
+	Lightness, Harmony, Distinctness, Contrast, CVD, Chroma float64
+}{0.2, 0.15, 0.2, 0.2, 0.15, 0.1}
+
+// 评分参数
+const (
+	targetLightnessRange = 60.0 // 明度阶梯期望覆盖的 CIE L* 跨度（系统提示词中的 20%–80%）
+	maxHarmonyOffset     = 30.0 // 色相偏离方案锚点的最大角度达到此值时和谐分为 0
+	minChromaBand        = 0.05 // 平均彩度的理想区间下限
+	maxChromaBand        = 0.16 // 平均彩度的理想区间上限
+	targetChromaSpread   = 0.1  // 最高与最低彩度之差达到此值时层次最佳
+)
+
+// cvdKinds 参与可辨性评分的色觉缺陷类型
+var cvdKinds = []string{"deuteranopia", "protanopia", "tritanopia"}
+
+// Score 配色评分，各分项与总分均为 0–100
+type Score struct {
+	Overall float64 `json:"overall"`
+	// Lightness 明度阶梯：跨度是否足够、各级间距是否均匀
+	Lightness float64 `json:"lightness"`
+	// Harmony 色相分布与最接近的经典配色方案的吻合程度
+	Harmony float64 `json:"harmony"`
+	// Distinctness 两两之间最小 ΔE2000
+	Distinctness float64 `json:"distinctness"`
+	// Contrast 配色内部能组成的最佳文字对比度
+	Contrast float64 `json:"contrast"`
+	// CVD 各类色盲模拟下两两之间最小 ΔE2000 的平均得分
+	CVD float64 `json:"cvd"`
+	// Chroma 平均彩度是否适中、彩度是否有主次
+	Chroma  float64 `json:"chroma"`
+	Metrics Metrics `json:"metrics"`
+}
+
+// Metrics 评分依据的原始指标
+type Metrics struct {
+	LightnessRange [2]float64         `json:"lightness_range"`
+	HarmonyScheme  string             `json:"harmony_scheme"`
+	HarmonyOffset  float64            `json:"harmony_offset"`
+	MinDeltaE      float64            `json:"min_delta_e"`
+	BestContrast   float64            `json:"best_contrast"`
+	BestContrastOn [2]string          `json:"best_contrast_pair"`
+	MinDeltaECVD   map[string]float64 `json:"min_delta_e_cvd"`
+	MeanChroma     float64            `json:"mean_chroma"`
+	ChromaSpread   float64            `json:"chroma_spread"`
+}
+
+// Evaluate 为配色打分，至少需要 2 个颜色
+func Evaluate(colors []string) (*Score, error) {
+	if len(colors) < 2 {
+		return nil, fmt.Errorf("palette needs at least 2 colors")
+	}
+	rgbs := make([]colorutil.RGB, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		rgbs[i] = c
+	}
+
+	s := &Score{Metrics: Metrics{MinDeltaECVD: make(map[string]float64, len(cvdKinds))}}
+	m := &s.Metrics
+
+	s.Lightness, m.LightnessRange = lightnessLadder(rgbs)
+	s.Harmony, m.HarmonyScheme, m.HarmonyOffset = harmony(colors)
+
+	m.MinDeltaE = colorutil.Round(minDeltaE(rgbs), 1)
+	s.Distinctness = 100 * ramp(m.MinDeltaE, 3, 20)
+
+	best, pair := bestContrast(rgbs)
+	m.BestContrast, m.BestContrastOn = colorutil.Round(best, 2), [2]string{rgbs[pair[0]].Hex(), rgbs[pair[1]].Hex()}
+	s.Contrast = contrastScore(best)
+
+	for _, kind := range cvdKinds {
+		simulated := make([]colorutil.RGB, len(rgbs))
+		for i, c := range rgbs {
+			simulated[i] = colorutil.SimulateCVD(c, kind)
+		}
+		d := colorutil.Round(minDeltaE(simulated), 1)
+		m.MinDeltaECVD[kind] = d
+		s.CVD += 100 * ramp(d, 1, 10) / float64(len(cvdKinds))
+	}
+
+	s.Chroma, m.MeanChroma, m.ChromaSpread = chromaBalance(rgbs)
+
+	s.Overall = weights.Lightness*s.Lightness + weights.Harmony*s.Harmony +
+		weights.Distinctness*s.Distinctness + weights.Contrast*s.Contrast +
+		weights.CVD*s.CVD + weights.Chroma*s.Chroma
+	for _, v := range []*float64{&s.Overall, &s.Lightness, &s.Harmony, &s.Distinctness, &s.Contrast, &s.CVD, &s.Chroma} {
+		*v = colorutil.Round(*v, 1)
+	}
+	return s, nil
+}
+
+// lightnessLadder 明度阶梯得分：以 L* 跨度相对目标的覆盖率为上限，其中 40% 再看排序后各级间距的均匀度
+func lightnessLadder(rgbs []colorutil.RGB) (float64, [2]float64) {
+	ls := make([]float64, len(rgbs))
+	for i, c := range rgbs {
+		ls[i] = colorutil.ToLab(c).L
+	}
+	sort.Float64s(ls)
+	span := ls[len(ls)-1] - ls[0]
+	coverage := math.Min(1, span/targetLightnessRange)
+
+	evenness := 0.0
+	if span > 0 {
+		ideal := span / float64(len(ls)-1)
+		variance := 0.0
+		for i := 1; i < len(ls); i++ {
+			d := ls[i] - ls[i-1] - ideal
+			variance += d * d
+		}
+		evenness = math.Max(0, 1-math.Sqrt(variance/float64(len(ls)-1))/ideal)
+	}
+	return 100 * coverage * (0.6 + 0.4*evenness), [2]float64{colorutil.Round(ls[0], 1), colorutil.Round(ls[len(ls)-1], 1)}
+}
+
+// chromaBalance 彩度平衡得分：平均彩度落在理想区间内，且最高与最低彩度拉开层次
+func chromaBalance(rgbs []colorutil.RGB) (float64, float64, float64) {
+	sum, lo, hi := 0.0, math.Inf(1), math.Inf(-1)
+	for _, c := range rgbs {
+		chroma := colorutil.ToOKLCH(c).C
+		sum += chroma
+		lo, hi = math.Min(lo, chroma), math.Max(hi, chroma)
+	}
+	mean, spread := sum/float64(len(rgbs)), hi-lo
+
+	band := 1.0
+	switch {
+	case mean < minChromaBand:
+		band = mean / minChromaBand
+	case mean > maxChromaBand:
+		band = math.Max(0, 1-(mean-maxChromaBand)/0.1)
+	}
+	return 100 * (0.5*band + 0.5*math.Min(1, spread/targetChromaSpread)), colorutil.Round(mean, 3), colorutil.Round(spread, 3)
+}
+
+// bestContrast 返回配色中对比度最高的一对颜色及其对比度
+func bestContrast(rgbs []colorutil.RGB) (float64, [2]int) {
+	best, pair := 0.0, [2]int{0, 1}
+	for i := range rgbs {
+		for j := i + 1; j < len(rgbs); j++ {
+			if r := colorutil.ContrastRatio(rgbs[i], rgbs[j]); r > best {
+				best, pair = r, [2]int{i, j}
+			}
+		}
+	}
+	return best, pair
+}
+
+// contrastScore 按 WCAG 档位分段映射对比度：3:1 得 40，4.5:1 得 70，7:1 得 90，12:1 及以上满分
+func contrastScore(ratio float64) float64 {
+	points := [][2]float64{{1, 0}, {3, 40}, {4.5, 70}, {7, 90}, {12, 100}}
+	for i := 1; i < len(points); i++ {
+		if ratio <= points[i][0] {
+			a, b := points[i-1], points[i]
+			return a[1] + (b[1]-a[1])*(ratio-a[0])/(b[0]-a[0])
+		}
+	}
+	return 100
+}
+
+func minDeltaE(rgbs []colorutil.RGB) float64 {
+	best := math.Inf(1)
+	for i := range rgbs {
+		for j := i + 1; j < len(rgbs); j++ {
+			best = math.Min(best, colorutil.DeltaE(rgbs[i], rgbs[j]))
+		}
+	}
+	return best
+}
+
+// ramp 将 v 从 [lo, hi] 线性映射到 [0, 1]，区间外截断
+func ramp(v, lo, hi float64) float64 {
+	return math.Max(0, math.Min(1, (v-lo)/(hi-lo)))
+}
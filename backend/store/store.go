@@ -0,0 +1,65 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"ai-color-palette/score"
+)
+
+// maxPalettes 内存中保留的配色数量上限，超出后淘汰最早的记录
+const maxPalettes = 1000
+
+// Palette 已生成的配色记录
+type Palette struct {
+	ID          string   `json:"id"`
+	Colors      []string `json:"colors"`
+	Advice      string   `json:"advice"`
+	Prompt      string   `json:"prompt"`
+	Description string   `json:"description"`
+	// Score 保存时由服务端计算的配色评分
+	Score     *score.Score `json:"score,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+var (
+	mu       sync.RWMutex
+	palettes = make(map[string]*Palette)
+	order    []string
+)
+
+// Save 保存配色并返回其ID
+func Save(p *Palette) string {
+	p.ID = newID()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	palettes[p.ID] = p
+	order = append(order, p.ID)
+	for len(order) > maxPalettes {
+		delete(palettes, order[0])
+		order = order[1:]
+	}
+	return p.ID
+}
+
+// Get 按ID读取配色
+func Get(id string) (*Palette, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := palettes[id]
+	return p, ok
+}
+
+func newID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().Format("150405")))
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,32 @@
+package theme
+
+import "ai-color-palette/colorutil"
+
+// Roles 从配色中推断的界面角色
+type Roles struct {
+	Primary   string
+	Secondary string
+	Tertiary  string
+	Surface   string
+}
+
+// AssignRoles 按顺序取主色/次色/点缀色，表面色取最亮（深色模式取最暗）的颜色
+func AssignRoles(colors []string, dark bool) Roles {
+	pick := func(i int) string {
+		if i < len(colors) {
+			return colors[i]
+		}
+		return colors[len(colors)-1]
+	}
+
+	surface := colors[0]
+	best := colorutil.RelativeLuminance(colorutil.MustParseHex(surface))
+	for _, color := range colors[1:] {
+		lum := colorutil.RelativeLuminance(colorutil.MustParseHex(color))
+		if (!dark && lum > best) || (dark && lum < best) {
+			surface, best = color, lum
+		}
+	}
+
+	return Roles{Primary: pick(0), Secondary: pick(1), Tertiary: pick(2), Surface: surface}
+}
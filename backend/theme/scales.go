@@ -0,0 +1,130 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	ScaleMaterial = "material"
+	ScaleTailwind = "tailwind"
+)
+
+// MaterialTones Material 风格色调阶，数值即 CIE L*（0 为黑，100 为白）
+var MaterialTones = []int{0, 5, 10, 15, 20, 25, 30, 35, 40, 50, 60, 70, 80, 90, 95, 98, 99, 100}
+
+// TailwindSteps Tailwind 风格色阶
+var TailwindSteps = []int{50, 100, 200, 300, 400, 500, 600, 700, 800, 900, 950}
+
+// tailwindLightness 与 TailwindSteps 对应的 OKLCH 明度；tailwindChroma 为相对彩度，
+// 两端收窄、中段饱满，参照 Tailwind v4 默认色板的分布
+var (
+	tailwindLightness = []float64{0.971, 0.936, 0.885, 0.808, 0.711, 0.637, 0.577, 0.505, 0.444, 0.396, 0.258}
+	tailwindChroma    = []float64{0.06, 0.14, 0.26, 0.48, 0.78, 0.96, 1, 0.88, 0.73, 0.58, 0.4}
+)
+
+// Tone 色阶中的一级
+type Tone struct {
+	Tone  int    `json:"tone"`
+	Color string `json:"color"`
+}
+
+// Scale 单个种子色的色阶
+type Scale struct {
+	Seed     string `json:"seed"`
+	Material []Tone `json:"material,omitempty"`
+	Tailwind []Tone `json:"tailwind,omitempty"`
+}
+
+// Scales 为每个颜色生成色阶，kinds 为空时生成全部类型
+func Scales(colors []string, kinds ...string) ([]Scale, error) {
+	material, tailwind := len(kinds) == 0, len(kinds) == 0
+	for _, kind := range kinds {
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case ScaleMaterial:
+			material = true
+		case ScaleTailwind:
+			tailwind = true
+		case "", "all":
+			material, tailwind = true, true
+		default:
+			return nil, fmt.Errorf("unknown scale kind: %s", kind)
+		}
+	}
+
+	scales := make([]Scale, 0, len(colors))
+	for _, color := range colors {
+		seed, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		s := Scale{Seed: seed.Hex()}
+		if material {
+			s.Material = MaterialScale(seed)
+		}
+		if tailwind {
+			s.Tailwind = TailwindScale(seed)
+		}
+		scales = append(scales, s)
+	}
+	return scales, nil
+}
+
+// MaterialScale 保持种子色的 OKLCH 色相与彩度，按 CIE L* 取各色调；
+// 超出色域时降低彩度，因此同一色调的不同色相亮度一致，色调差即可推算对比度
+func MaterialScale(seed colorutil.RGB) []Tone {
+	lch := colorutil.ToOKLCH(seed)
+	tones := make([]Tone, len(MaterialTones))
+	for i, tone := range MaterialTones {
+		tones[i] = Tone{Tone: tone, Color: atLabLightness(lch, float64(tone)).Hex()}
+	}
+	return tones
+}
+
+// atLabLightness 二分查找 OKLCH 明度，使结果的 CIE L* 等于 target
+func atLabLightness(lch colorutil.OKLCH, target float64) colorutil.RGB {
+	switch {
+	case target <= 0:
+		return colorutil.Black
+	case target >= 100:
+		return colorutil.White
+	}
+	lo, hi := 0.0, 1.0
+	var c colorutil.RGB
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		c = colorutil.OKLCH{L: mid, C: lch.C, H: lch.H}.RGB()
+		if colorutil.ToLab(c).L < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return c
+}
+
+// TailwindScale 以 OKLCH 固定明度生成 50–950 色阶；与种子明度最接近的一级直接使用种子色，
+// 其余级别按相对彩度曲线缩放种子彩度
+func TailwindScale(seed colorutil.RGB) []Tone {
+	lch := colorutil.ToOKLCH(seed)
+	anchor := 0
+	for i, l := range tailwindLightness {
+		if math.Abs(l-lch.L) < math.Abs(tailwindLightness[anchor]-lch.L) {
+			anchor = i
+		}
+	}
+
+	tones := make([]Tone, len(TailwindSteps))
+	for i, step := range TailwindSteps {
+		color := seed
+		if i != anchor {
+			chroma := lch.C * tailwindChroma[i] / tailwindChroma[anchor]
+			color = colorutil.OKLCH{L: tailwindLightness[i], C: math.Min(chroma, 0.37), H: lch.H}.RGB()
+		}
+		tones[i] = Tone{Tone: step, Color: color.Hex()}
+	}
+	return tones
+}
@@ -0,0 +1,223 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+
+	"ai-color-palette/colorutil"
+)
+
+const (
+	minUIContrast = 3.0
+	minBodyText   = 7.0
+)
+
+// statusHues 成功、警告、错误在 OKLCH 中的参考色相
+var statusHues = map[string]float64{"success": 145, "warning": 75, "error": 27}
+
+// SemanticRoles 配色中承担各语义角色的颜色下标
+type SemanticRoles struct {
+	Primary   int `json:"primary"`
+	Secondary int `json:"secondary"`
+	Accent    int `json:"accent"`
+	Neutral   int `json:"neutral"`
+}
+
+// Validate 检查下标是否都落在配色范围内
+func (r SemanticRoles) Validate(n int) error {
+	for name, i := range map[string]int{"primary": r.Primary, "secondary": r.Secondary, "accent": r.Accent, "neutral": r.Neutral} {
+		if i < 0 || i >= n {
+			return fmt.Errorf("role %s index %d out of range [0, %d)", name, i, n)
+		}
+	}
+	return nil
+}
+
+// InferSemanticRoles 不借助模型时按 AssignRoles 的规则推断角色：最亮的颜色作为中性色
+func InferSemanticRoles(colors []string) SemanticRoles {
+	r := AssignRoles(colors, false)
+	index := func(hex string) int {
+		for i, c := range colors {
+			if c == hex {
+				return i
+			}
+		}
+		return 0
+	}
+	return SemanticRoles{
+		Primary:   index(r.Primary),
+		Secondary: index(r.Secondary),
+		Accent:    index(r.Tertiary),
+		Neutral:   index(r.Surface),
+	}
+}
+
+// Scheme 单一明暗模式下的完整语义色
+type Scheme struct {
+	Background       string          `json:"background"`
+	OnBackground     string          `json:"on_background"`
+	Surface          string          `json:"surface"`
+	OnSurface        string          `json:"on_surface"`
+	SurfaceVariant   string          `json:"surface_variant"`
+	OnSurfaceVariant string          `json:"on_surface_variant"`
+	Border           string          `json:"border"`
+	Primary          string          `json:"primary"`
+	OnPrimary        string          `json:"on_primary"`
+	Secondary        string          `json:"secondary"`
+	OnSecondary      string          `json:"on_secondary"`
+	Accent           string          `json:"accent"`
+	OnAccent         string          `json:"on_accent"`
+	Success          string          `json:"success"`
+	OnSuccess        string          `json:"on_success"`
+	Warning          string          `json:"warning"`
+	OnWarning        string          `json:"on_warning"`
+	Error            string          `json:"error"`
+	OnError          string          `json:"on_error"`
+	Contrast         []ContrastCheck `json:"contrast"`
+}
+
+// SemanticTheme 由配色生成的浅色与深色语义主题
+type SemanticTheme struct {
+	Colors []string      `json:"colors"`
+	Roles  SemanticRoles `json:"roles"`
+	Light  Scheme        `json:"light"`
+	Dark   Scheme        `json:"dark"`
+}
+
+// GenerateSemantic 将配色按角色映射为浅色与深色两套语义主题，
+// 所有文字色相对其底色至少满足 4.5:1，正文色尽量达到 7:1
+func GenerateSemantic(colors []string, roles *SemanticRoles) (*SemanticTheme, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette has no colors")
+	}
+	normalized := make([]string, len(colors))
+	lchs := make([]colorutil.OKLCH, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = c.Hex()
+		lchs[i] = colorutil.ToOKLCH(c)
+	}
+
+	r := InferSemanticRoles(normalized)
+	if roles != nil {
+		if err := roles.Validate(len(colors)); err != nil {
+			return nil, err
+		}
+		r = *roles
+	}
+
+	return &SemanticTheme{
+		Colors: normalized,
+		Roles:  r,
+		Light:  buildScheme(lchs, r, false),
+		Dark:   buildScheme(lchs, r, true),
+	}, nil
+}
+
+func buildScheme(lchs []colorutil.OKLCH, r SemanticRoles, dark bool) Scheme {
+	neutral := lchs[r.Neutral]
+	bgL, surfaceL, variantL := 0.98, 1.0, 0.93
+	textL, mutedL, borderL := 0.24, 0.45, 0.82
+	if dark {
+		bgL, surfaceL, variantL = 0.17, 0.21, 0.28
+		textL, mutedL, borderL = 0.94, 0.78, 0.4
+	}
+
+	background := tinted(neutral, bgL, 0.015)
+	surface := tinted(neutral, surfaceL, 0.01)
+	variant := tinted(neutral, variantL, 0.02)
+
+	s := Scheme{
+		Background:       background.Hex(),
+		OnBackground:     colorutil.EnsureContrast(tinted(neutral, textL, 0.03), background, minBodyText).Hex(),
+		Surface:          surface.Hex(),
+		OnSurface:        colorutil.EnsureContrast(tinted(neutral, textL, 0.03), surface, minBodyText).Hex(),
+		SurfaceVariant:   variant.Hex(),
+		OnSurfaceVariant: colorutil.EnsureContrast(tinted(neutral, mutedL, 0.03), variant, minTextContrast).Hex(),
+		Border:           colorutil.EnsureContrast(tinted(neutral, borderL, 0.03), surface, minUIContrast).Hex(),
+	}
+
+	// 品牌色保留色相与彩度，仅调整明度使其在背景上可辨识；深色模式整体提亮
+	brand := func(lch colorutil.OKLCH) (string, string) {
+		if dark && lch.L < 0.7 {
+			lch.L = 0.72
+		}
+		fill := colorutil.EnsureContrast(lch.RGB(), background, minUIContrast)
+		return fill.Hex(), onColor(fill).Hex()
+	}
+	s.Primary, s.OnPrimary = brand(lchs[r.Primary])
+	s.Secondary, s.OnSecondary = brand(lchs[r.Secondary])
+	s.Accent, s.OnAccent = brand(lchs[r.Accent])
+
+	primary := lchs[r.Primary]
+	chroma := clamp(primary.C, 0.13, 0.2)
+	status := func(kind string, l float64) (string, string) {
+		hue := harmonizeHue(statusHues[kind], primary)
+		if dark {
+			l = math.Max(l, 0.72)
+		}
+		fill := colorutil.EnsureContrast(colorutil.OKLCH{L: l, C: chroma, H: hue}.RGB(), background, minUIContrast)
+		return fill.Hex(), onColor(fill).Hex()
+	}
+	s.Success, s.OnSuccess = status("success", 0.55)
+	s.Warning, s.OnWarning = status("warning", 0.62)
+	s.Error, s.OnError = status("error", 0.55)
+
+	s.Contrast = s.checkContrast()
+	return s
+}
+
+// harmonizeHue 将状态色相向主色色相偏移少许（最多 8°），使其与配色协调又不失语义
+func harmonizeHue(hue float64, primary colorutil.OKLCH) float64 {
+	if primary.C < 0.04 {
+		return hue
+	}
+	diff := math.Mod(primary.H-hue+540, 360) - 180
+	shift := clamp(diff*0.15, -8, 8)
+	return colorutil.NormalizeHue(hue + shift)
+}
+
+// onColor 在彩色底上放置的文字色：带底色色相的近白或近黑，不足 4.5:1 时退回纯黑白
+func onColor(fill colorutil.RGB) colorutil.RGB {
+	lch := colorutil.ToOKLCH(fill)
+	l := 0.99
+	if colorutil.BestTextColor(fill) == colorutil.Black {
+		l = 0.18
+	}
+	text := tinted(lch, l, 0.02)
+	if colorutil.ContrastRatio(text, fill) < minTextContrast {
+		return colorutil.BestTextColor(fill)
+	}
+	return text
+}
+
+func (s Scheme) checkContrast() []ContrastCheck {
+	check := func(slot, color, against string, required float64) ContrastCheck {
+		ratio := colorutil.ContrastRatio(colorutil.MustParseHex(color), colorutil.MustParseHex(against))
+		return ContrastCheck{
+			Slot:     slot,
+			Color:    color,
+			Against:  against,
+			Ratio:    math.Round(ratio*100) / 100,
+			Required: required,
+			Level:    colorutil.ContrastLevel(ratio),
+			Pass:     ratio >= required,
+		}
+	}
+	return []ContrastCheck{
+		check("on_background", s.OnBackground, s.Background, minTextContrast),
+		check("on_surface", s.OnSurface, s.Surface, minTextContrast),
+		check("on_surface_variant", s.OnSurfaceVariant, s.SurfaceVariant, minTextContrast),
+		check("on_primary", s.OnPrimary, s.Primary, minTextContrast),
+		check("on_secondary", s.OnSecondary, s.Secondary, minTextContrast),
+		check("on_accent", s.OnAccent, s.Accent, minTextContrast),
+		check("on_success", s.OnSuccess, s.Success, minTextContrast),
+		check("on_warning", s.OnWarning, s.Warning, minTextContrast),
+		check("on_error", s.OnError, s.Error, minTextContrast),
+		check("primary", s.Primary, s.Background, minUIContrast),
+		check("border", s.Border, s.Surface, minUIContrast),
+	}
+}
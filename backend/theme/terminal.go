@@ -0,0 +1,224 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// ANSINames 16 色 ANSI 的标准顺序
+var ANSINames = [16]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"brightBlack", "brightRed", "brightGreen", "brightYellow",
+	"brightBlue", "brightMagenta", "brightCyan", "brightWhite",
+}
+
+// ansiHues ANSI 彩色槽位在 OKLCH 中的参考色相（red..cyan）
+var ansiHues = [6]float64{29, 142, 110, 264, 328, 195}
+
+const (
+	minTextContrast   = 4.5
+	minCursorContrast = 3.0
+	minMutedContrast  = 2.0
+	paletteHueSnap    = 30.0
+)
+
+// TerminalOptions 终端主题生成参数
+type TerminalOptions struct {
+	Name        string
+	Description string
+	Variant     string // dark 或 light
+}
+
+// ContrastCheck 单个颜色相对背景的对比度检查结果
+type ContrastCheck struct {
+	Slot     string  `json:"slot"`
+	Color    string  `json:"color"`
+	Against  string  `json:"against,omitempty"`
+	Ratio    float64 `json:"ratio"`
+	Required float64 `json:"required"`
+	Level    string  `json:"level"`
+	Pass     bool    `json:"pass"`
+}
+
+// Terminal 由配色映射出的终端/编辑器主题
+type Terminal struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Variant     string          `json:"variant"`
+	Background  string          `json:"background"`
+	Foreground  string          `json:"foreground"`
+	Cursor      string          `json:"cursor"`
+	CursorText  string          `json:"cursor_text"`
+	Selection   string          `json:"selection"`
+	ANSI        [16]string      `json:"ansi"`
+	Contrast    []ContrastCheck `json:"contrast"`
+}
+
+// GenerateTerminal 将配色映射为完整的 16 色 ANSI 方案及背景、前景、光标色
+func GenerateTerminal(colors []string, opts TerminalOptions) (*Terminal, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette has no colors")
+	}
+	palette := make([]colorutil.RGB, 0, len(colors))
+	for _, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		palette = append(palette, c)
+	}
+
+	variant := strings.ToLower(strings.TrimSpace(opts.Variant))
+	if variant != "light" {
+		variant = "dark"
+	}
+	dark := variant == "dark"
+	if opts.Name == "" {
+		opts.Name = "PaletteFlow " + strings.ToUpper(variant[:1]) + variant[1:]
+	}
+
+	lchs := make([]colorutil.OKLCH, len(palette))
+	for i, c := range palette {
+		lchs[i] = colorutil.ToOKLCH(c)
+	}
+	darkest, lightest, accent := 0, 0, 0
+	for i, lch := range lchs {
+		if lch.L < lchs[darkest].L {
+			darkest = i
+		}
+		if lch.L > lchs[lightest].L {
+			lightest = i
+		}
+		if lch.C > lchs[accent].C {
+			accent = i
+		}
+	}
+
+	// 背景与前景沿用配色中最暗/最亮颜色的色相，仅压低彩度并推到合适明度
+	bgSource, fgSource := lchs[darkest], lchs[lightest]
+	bgL, fgL := 0.2, 0.92
+	if !dark {
+		bgSource, fgSource = lchs[lightest], lchs[darkest]
+		bgL, fgL = 0.97, 0.3
+	}
+	background := tinted(bgSource, bgL, 0.03)
+	foreground := colorutil.EnsureContrast(tinted(fgSource, fgL, 0.04), background, 7)
+
+	t := &Terminal{
+		Name:        opts.Name,
+		Description: strings.TrimSpace(opts.Description),
+		Variant:     variant,
+		Background:  background.Hex(),
+		Foreground:  foreground.Hex(),
+	}
+
+	normalL, brightL := 0.7, 0.8
+	if !dark {
+		normalL, brightL = 0.5, 0.42
+	}
+	for slot, hue := range ansiHues {
+		base := ansiSlotColor(lchs, hue)
+		normal := colorutil.EnsureContrast(colorutil.OKLCH{L: normalL, C: base.C, H: base.H}.RGB(), background, minTextContrast)
+		bright := colorutil.EnsureContrast(colorutil.OKLCH{L: brightL, C: base.C * 1.1, H: base.H}.RGB(), background, minTextContrast)
+		t.ANSI[slot+1] = normal.Hex()
+		t.ANSI[slot+9] = bright.Hex()
+	}
+
+	bgLCH := colorutil.ToOKLCH(background)
+	fgLCH := colorutil.ToOKLCH(foreground)
+	if dark {
+		t.ANSI[0] = tinted(bgLCH, bgLCH.L+0.08, 0.02).Hex()
+		t.ANSI[8] = colorutil.EnsureContrast(tinted(bgLCH, 0.55, 0.02), background, minMutedContrast).Hex()
+		t.ANSI[7] = tinted(fgLCH, fgLCH.L-0.1, 0.02).Hex()
+		t.ANSI[15] = foreground.Hex()
+	} else {
+		t.ANSI[0] = foreground.Hex()
+		t.ANSI[8] = colorutil.EnsureContrast(tinted(fgLCH, 0.55, 0.02), background, minMutedContrast).Hex()
+		t.ANSI[7] = tinted(bgLCH, bgLCH.L-0.12, 0.02).Hex()
+		t.ANSI[15] = tinted(bgLCH, bgLCH.L-0.05, 0.02).Hex()
+	}
+
+	cursor := colorutil.EnsureContrast(palette[accent], background, minCursorContrast)
+	t.Cursor = cursor.Hex()
+	t.CursorText = colorutil.BestTextColor(cursor).Hex()
+	selL := bgLCH.L + 0.12
+	if !dark {
+		selL = bgLCH.L - 0.1
+	}
+	t.Selection = tinted(colorutil.ToOKLCH(cursor), selL, 0.05).Hex()
+
+	t.Contrast = t.checkContrast()
+	return t, nil
+}
+
+// tinted 保留色相，以给定明度与彩度上限生成颜色
+func tinted(source colorutil.OKLCH, l, maxChroma float64) colorutil.RGB {
+	return colorutil.OKLCH{L: l, C: math.Min(source.C, maxChroma), H: source.H}.RGB()
+}
+
+// ansiSlotColor 若配色中有接近目标色相的颜色则沿用其色相与彩度，否则以配色平均彩度生成标准色相
+func ansiSlotColor(lchs []colorutil.OKLCH, hue float64) colorutil.OKLCH {
+	best := -1
+	for i, lch := range lchs {
+		if lch.C < 0.04 {
+			continue
+		}
+		if colorutil.HueDistance(lch.H, hue) > paletteHueSnap {
+			continue
+		}
+		if best < 0 || colorutil.HueDistance(lch.H, hue) < colorutil.HueDistance(lchs[best].H, hue) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return colorutil.OKLCH{L: lchs[best].L, C: clamp(lchs[best].C, 0.08, 0.2), H: lchs[best].H}
+	}
+
+	chroma := 0.0
+	for _, lch := range lchs {
+		chroma += lch.C
+	}
+	chroma /= float64(len(lchs))
+	return colorutil.OKLCH{C: clamp(chroma, 0.08, 0.16), H: hue}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(hi, math.Max(lo, v))
+}
+
+func (t *Terminal) checkContrast() []ContrastCheck {
+	background := colorutil.MustParseHex(t.Background)
+	check := func(slot, color string, required float64) ContrastCheck {
+		ratio := colorutil.ContrastRatio(colorutil.MustParseHex(color), background)
+		return ContrastCheck{
+			Slot:     slot,
+			Color:    color,
+			Ratio:    math.Round(ratio*100) / 100,
+			Required: required,
+			Level:    colorutil.ContrastLevel(ratio),
+			Pass:     ratio >= required,
+		}
+	}
+
+	checks := []ContrastCheck{
+		check("foreground", t.Foreground, 7),
+		check("cursor", t.Cursor, minCursorContrast),
+	}
+	for i, color := range t.ANSI {
+		required := minTextContrast
+		switch ANSINames[i] {
+		case "brightBlack":
+			required = minMutedContrast
+		case "black", "white", "brightWhite":
+			// 与背景同侧的中性色本就用于低对比区域，不做要求
+			if (t.Variant == "dark" && ANSINames[i] == "black") || (t.Variant == "light" && ANSINames[i] != "black") {
+				required = 1
+			}
+		}
+		checks = append(checks, check(ANSINames[i], color, required))
+	}
+	return checks
+}
@@ -0,0 +1,194 @@
+package theme
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"ai-color-palette/colorutil"
+)
+
+// commentLines 将描述拆分为注释行
+func commentLines(prefix, text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(&b, "%s %s\n", prefix, line)
+		}
+	}
+	return b.String()
+}
+
+// Alacritty 生成 alacritty.toml 颜色配置
+func (t *Terminal) Alacritty() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s - generated by PaletteFlow\n", t.Name)
+	b.WriteString(commentLines("#", t.Description))
+	fmt.Fprintf(&b, "\n[colors.primary]\nbackground = %q\nforeground = %q\n", t.Background, t.Foreground)
+	fmt.Fprintf(&b, "\n[colors.cursor]\ntext = %q\ncursor = %q\n", t.CursorText, t.Cursor)
+	fmt.Fprintf(&b, "\n[colors.selection]\ntext = \"CellForeground\"\nbackground = %q\n", t.Selection)
+	for _, group := range []struct {
+		name   string
+		offset int
+	}{{"normal", 0}, {"bright", 8}} {
+		fmt.Fprintf(&b, "\n[colors.%s]\n", group.name)
+		for i := 0; i < 8; i++ {
+			fmt.Fprintf(&b, "%s = %q\n", ANSINames[i], t.ANSI[group.offset+i])
+		}
+	}
+	return []byte(b.String())
+}
+
+// Kitty 生成 kitty.conf 颜色配置
+func (t *Terminal) Kitty() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s - generated by PaletteFlow\n", t.Name)
+	b.WriteString(commentLines("#", t.Description))
+	fmt.Fprintf(&b, "\nbackground %s\nforeground %s\n", t.Background, t.Foreground)
+	fmt.Fprintf(&b, "cursor %s\ncursor_text_color %s\n", t.Cursor, t.CursorText)
+	fmt.Fprintf(&b, "selection_background %s\nselection_foreground none\n\n", t.Selection)
+	for i, color := range t.ANSI {
+		fmt.Fprintf(&b, "# %s\ncolor%d %s\n", ANSINames[i], i, color)
+	}
+	return []byte(b.String())
+}
+
+// WindowsTerminal 生成 Windows Terminal settings.json 中 schemes 数组的一项
+func (t *Terminal) WindowsTerminal() ([]byte, error) {
+	// Windows Terminal 使用 purple 命名品红色槽位
+	scheme := map[string]string{
+		"name":                t.Name,
+		"description":         t.Description,
+		"background":          t.Background,
+		"foreground":          t.Foreground,
+		"cursorColor":         t.Cursor,
+		"selectionBackground": t.Selection,
+	}
+	for i, name := range ANSINames {
+		key := strings.Replace(name, "magenta", "purple", 1)
+		key = strings.Replace(key, "Magenta", "Purple", 1)
+		scheme[key] = t.ANSI[i]
+	}
+	return json.MarshalIndent(scheme, "", "  ")
+}
+
+// ITerm2 生成 .itermcolors（plist XML）
+func (t *Terminal) ITerm2() []byte {
+	entries := [][2]string{
+		{"Background Color", t.Background},
+		{"Foreground Color", t.Foreground},
+		{"Bold Color", t.Foreground},
+		{"Cursor Color", t.Cursor},
+		{"Cursor Text Color", t.CursorText},
+		{"Selection Color", t.Selection},
+		{"Selected Text Color", t.Foreground},
+	}
+	for i, color := range t.ANSI {
+		entries = append(entries, [2]string{fmt.Sprintf("Ansi %d Color", i), color})
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	fmt.Fprintf(&b, "<!-- %s - generated by PaletteFlow -->\n", xmlComment(t.Name))
+	if t.Description != "" {
+		fmt.Fprintf(&b, "<!-- %s -->\n", xmlComment(t.Description))
+	}
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	for _, entry := range entries {
+		r, g, bl := colorutil.MustParseHex(entry[1]).Floats()
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<dict>\n", entry[0])
+		fmt.Fprintf(&b, "\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n")
+		fmt.Fprintf(&b, "\t\t<key>Blue Component</key>\n\t\t<real>%.6f</real>\n", bl)
+		fmt.Fprintf(&b, "\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n")
+		fmt.Fprintf(&b, "\t\t<key>Green Component</key>\n\t\t<real>%.6f</real>\n", g)
+		fmt.Fprintf(&b, "\t\t<key>Red Component</key>\n\t\t<real>%.6f</real>\n", r)
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return []byte(b.String())
+}
+
+// xmlComment 去除注释中不允许出现的 "--"，并避免以 "-" 结尾；
+// "---" 替换一次后仍含 "--"，需反复替换
+func xmlComment(text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	for strings.Contains(text, "--") {
+		text = strings.ReplaceAll(text, "--", "- -")
+	}
+	if strings.HasSuffix(text, "-") {
+		text += " "
+	}
+	return text
+}
+
+type vscodeTokenColor struct {
+	Name     string            `json:"name"`
+	Scope    []string          `json:"scope"`
+	Settings map[string]string `json:"settings"`
+}
+
+// VSCode 生成 VS Code 颜色主题 JSON
+func (t *Terminal) VSCode() ([]byte, error) {
+	uiType := "dark"
+	if t.Variant == "light" {
+		uiType = "light"
+	}
+	bg := colorutil.ToOKLCH(colorutil.MustParseHex(t.Background))
+	panelL := bg.L - 0.03
+	if t.Variant == "light" {
+		panelL = bg.L - 0.04
+	}
+	panel := tinted(bg, panelL, 0.03).Hex()
+	muted := t.ANSI[8]
+
+	colors := map[string]string{
+		"editor.background":                 t.Background,
+		"editor.foreground":                 t.Foreground,
+		"editorCursor.foreground":           t.Cursor,
+		"editor.selectionBackground":        t.Selection,
+		"editorLineNumber.foreground":       muted,
+		"editorLineNumber.activeForeground": t.Foreground,
+		"activityBar.background":            panel,
+		"activityBar.foreground":            t.Foreground,
+		"sideBar.background":                panel,
+		"sideBar.foreground":                t.Foreground,
+		"statusBar.background":              t.Cursor,
+		"statusBar.foreground":              t.CursorText,
+		"titleBar.activeBackground":         panel,
+		"titleBar.activeForeground":         t.Foreground,
+		"tab.activeBackground":              t.Background,
+		"tab.inactiveBackground":            panel,
+		"focusBorder":                       t.Cursor,
+		"button.background":                 t.Cursor,
+		"button.foreground":                 t.CursorText,
+		"terminal.background":               t.Background,
+		"terminal.foreground":               t.Foreground,
+		"terminalCursor.foreground":         t.Cursor,
+	}
+	for i, name := range ANSINames {
+		colors["terminal.ansi"+strings.ToUpper(name[:1])+name[1:]] = t.ANSI[i]
+	}
+
+	token := func(name, color string, scopes ...string) vscodeTokenColor {
+		return vscodeTokenColor{Name: name, Scope: scopes, Settings: map[string]string{"foreground": color}}
+	}
+	theme := map[string]interface{}{
+		"name":        t.Name,
+		"description": t.Description,
+		"type":        uiType,
+		"colors":      colors,
+		"tokenColors": []vscodeTokenColor{
+			token("Comment", muted, "comment", "punctuation.definition.comment"),
+			token("Keyword", t.ANSI[5], "keyword", "storage.type", "storage.modifier"),
+			token("String", t.ANSI[2], "string"),
+			token("Number", t.ANSI[3], "constant.numeric", "constant.language"),
+			token("Function", t.ANSI[4], "entity.name.function", "support.function"),
+			token("Type", t.ANSI[6], "entity.name.type", "support.type", "entity.name.class"),
+			token("Variable", t.Foreground, "variable", "meta.definition.variable"),
+			token("Invalid", t.ANSI[1], "invalid"),
+		},
+	}
+	return json.MarshalIndent(theme, "", "  ")
+}
@@ -0,0 +1,222 @@
+// Package wallpaper 由配色生成装饰性背景图：网格渐变、色块、低多边形与条纹
+package wallpaper
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"ai-color-palette/colorutil"
+	"ai-color-palette/gradient"
+	"ai-color-palette/preview"
+)
+
+// 支持的风格
+const (
+	StyleMesh    = "mesh"
+	StyleBlobs   = "blobs"
+	StyleLowPoly = "lowpoly"
+	StyleStripes = "stripes"
+)
+
+const (
+	minSize = 16
+	maxSize = 4096
+)
+
+type builder func(s *preview.Scene, colors []string, rng *rand.Rand)
+
+var styles = map[string]builder{
+	StyleMesh:    mesh,
+	StyleBlobs:   blobs,
+	StyleLowPoly: lowPoly,
+	StyleStripes: stripes,
+}
+
+// Styles 返回支持的风格
+func Styles() []string {
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Options 背景图参数
+type Options struct {
+	Style  string
+	Width  int
+	Height int
+	// Seed 随机种子，相同的配色、参数与种子总是得到相同的图
+	Seed int64
+}
+
+// Build 生成背景图画面，可用 Encode 输出 SVG 或 PNG
+func Build(colors []string, opts Options) (*preview.Scene, error) {
+	build, ok := styles[strings.ToLower(opts.Style)]
+	if !ok {
+		return nil, fmt.Errorf("unknown style: %s", opts.Style)
+	}
+	if opts.Width < minSize || opts.Width > maxSize || opts.Height < minSize || opts.Height > maxSize {
+		return nil, fmt.Errorf("size must be between %d and %d pixels", minSize, maxSize)
+	}
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette has no colors")
+	}
+	normalized := make([]string, len(colors))
+	for i, color := range colors {
+		c, err := colorutil.ParseHex(color)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = c.Hex()
+	}
+
+	s := preview.NewScene(opts.Width, opts.Height)
+	build(s, normalized, rand.New(rand.NewSource(opts.Seed)))
+	return s, nil
+}
+
+// byLightness 按 OKLab 明度从亮到暗排序的副本
+func byLightness(colors []string) []string {
+	sorted := append([]string(nil), colors...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return colorutil.ToOKLab(colorutil.MustParseHex(sorted[i])).L > colorutil.ToOKLab(colorutil.MustParseHex(sorted[j])).L
+	})
+	return sorted
+}
+
+// mesh 在配色平均色的底上叠加多个大半径光斑，得到柔和的网格渐变
+func mesh(s *preview.Scene, colors []string, rng *rand.Rand) {
+	base := colorutil.MustParseHex(colors[0])
+	for i, color := range colors[1:] {
+		base = colorutil.Mix(base, colorutil.MustParseHex(color), 1/float64(i+2))
+	}
+	s.Fill(base.Hex())
+
+	w, h := float64(s.Width), float64(s.Height)
+	size := math.Max(w, h)
+	count := int(math.Max(6, float64(len(colors)*2)))
+	for i := 0; i < count; i++ {
+		s.Glow(rng.Float64()*w, rng.Float64()*h, size*(0.45+rng.Float64()*0.4), colors[i%len(colors)], 0.95)
+	}
+}
+
+// blobs 在最亮颜色的底上放置半透明的有机色块，轮廓由若干正弦波叠加扰动
+func blobs(s *preview.Scene, colors []string, rng *rand.Rand) {
+	sorted := byLightness(colors)
+	s.Fill(sorted[0])
+	fills := sorted[1:]
+	if len(fills) == 0 {
+		fills = sorted
+	}
+
+	w, h := float64(s.Width), float64(s.Height)
+	unit := math.Min(w, h)
+	count := 5 + rng.Intn(4)
+	for i := 0; i < count; i++ {
+		cx, cy := rng.Float64()*w, rng.Float64()*h
+		radius := unit * (0.18 + rng.Float64()*0.22)
+		var amps, phases [3]float64
+		for k := range amps {
+			amps[k] = 0.04 + rng.Float64()*0.1
+			phases[k] = rng.Float64() * 2 * math.Pi
+		}
+		const segments = 96
+		points := make([][2]float64, segments)
+		for j := range points {
+			theta := 2 * math.Pi * float64(j) / segments
+			r := 1.0
+			for k := range amps {
+				r += amps[k] * math.Sin(float64(k+2)*theta+phases[k])
+			}
+			points[j] = [2]float64{cx + radius*r*math.Cos(theta), cy + radius*r*math.Sin(theta)}
+		}
+		s.Polygon(points, fills[i%len(fills)], 0.85)
+	}
+}
+
+// lowPoly 将抖动网格剖分为三角形，按随机方向的渐变取色并逐片微调明度形成棱面感
+func lowPoly(s *preview.Scene, colors []string, rng *rand.Rand) {
+	w, h := float64(s.Width), float64(s.Height)
+	cell := math.Max(w, h) / 12
+	cols, rows := int(math.Ceil(w/cell)), int(math.Ceil(h/cell))
+	cw, ch := w/float64(cols), h/float64(rows)
+
+	grid := make([][][2]float64, rows+1)
+	for y := range grid {
+		grid[y] = make([][2]float64, cols+1)
+		for x := range grid[y] {
+			px, py := float64(x)*cw, float64(y)*ch
+			// 边界上的点只沿边抖动，保证画面铺满
+			if x > 0 && x < cols {
+				px += (rng.Float64() - 0.5) * cw * 0.7
+			}
+			if y > 0 && y < rows {
+				py += (rng.Float64() - 0.5) * ch * 0.7
+			}
+			grid[y][x] = [2]float64{px, py}
+		}
+	}
+
+	stops := byLightness(colors)
+	if len(stops) == 1 {
+		stops = append(stops, stops[0])
+	}
+	g, _ := gradient.Build(stops, gradient.Options{Space: gradient.SpaceOKLab, Samples: 2})
+	angle := rng.Float64() * 2 * math.Pi
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	extent := math.Abs(dx)*w + math.Abs(dy)*h
+	shade := func(tri [][2]float64) string {
+		cx, cy := (tri[0][0]+tri[1][0]+tri[2][0])/3, (tri[0][1]+tri[1][1]+tri[2][1])/3
+		t := ((cx-w/2)*dx+(cy-h/2)*dy)/extent + 0.5
+		lch := colorutil.ToOKLCH(g.At(math.Max(0, math.Min(1, t+(rng.Float64()-0.5)*0.08))))
+		lch.L += (rng.Float64() - 0.5) * 0.05
+		return lch.RGB().Hex()
+	}
+
+	s.Fill(stops[len(stops)/2])
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			a, b, c, d := grid[y][x], grid[y][x+1], grid[y+1][x+1], grid[y+1][x]
+			tris := [][][2]float64{{a, b, c}, {a, c, d}}
+			if rng.Intn(2) == 0 {
+				tris = [][][2]float64{{a, b, d}, {b, c, d}}
+			}
+			for _, tri := range tris {
+				s.Facet(tri, shade(tri))
+			}
+		}
+	}
+}
+
+// stripes 沿随机角度铺设宽窄不一的条纹，按配色顺序循环取色
+func stripes(s *preview.Scene, colors []string, rng *rand.Rand) {
+	w, h := float64(s.Width), float64(s.Height)
+	diag := math.Hypot(w, h)
+	angle := (rng.Float64()*60 - 30 + 45) * math.Pi / 180
+	// u 为条纹推进方向，v 为条纹延伸方向
+	ux, uy := math.Cos(angle), math.Sin(angle)
+	vx, vy := -uy, ux
+	cx, cy := w/2, h/2
+
+	s.Fill(colors[0])
+	offset := rng.Intn(len(colors))
+	for pos, i := -diag/2, 0; pos < diag/2; i++ {
+		width := diag * (0.03 + rng.Float64()*0.09)
+		corner := func(along, across float64) [2]float64 {
+			return [2]float64{cx + ux*along + vx*across, cy + uy*along + vy*across}
+		}
+		// 多铺 1 像素压在下一条下面，避免相邻条纹间出现抗锯齿接缝
+		s.Polygon([][2]float64{
+			corner(pos, -diag/2),
+			corner(pos+width+1, -diag/2),
+			corner(pos+width+1, diag/2),
+			corner(pos, diag/2),
+		}, colors[(i+offset)%len(colors)], 1)
+		pos += width
+	}
+}
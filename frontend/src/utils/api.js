@@ -8,9 +8,14 @@ const apiClient = axios.create({
   timeout: 10000
 })
 
-// 生成配色方案
-export const generatePalette = (prompt) => {
-  return apiClient.post('/generate-palette', { prompt })
+// 生成配色方案，image 为可选的 base64 / data URL 参考图
+// options.purpose 可选 sequential / diverging / categorical，用于图表配色
+// options.candidates 为 2–6 时返回按评分排序的多个候选（response.candidates）
+// options.agentic 为 true 时模型先调用对比度与色盲模拟工具自检再提交
+// 模型输出经服务端修复时 response.repairs 列出所做的修复
+// options.no_cache 为 true 时不使用缓存结果；response.cached 表示结果是否来自缓存
+export const generatePalette = (prompt, image, options = {}) => {
+  return apiClient.post('/generate-palette', image ? { prompt, image, ...options } : { prompt, ...options })
 }
 
 // 单色微调：仅替换指定位置的颜色
@@ -26,6 +31,119 @@ export const refinePalette = (currentColors, prompt) => {
   })
 }
 
+// 导出配色为平台原生格式（android / ios / flutter / swiftui）
+export const exportPalette = (format, palette) => {
+  return apiClient.post('/export', { format, ...palette }, { responseType: 'blob' })
+}
+
+// 由配色生成终端/编辑器主题（含对比度检查）
+export const generateTerminalTheme = (colors, advice, variant = 'dark') => {
+  return apiClient.post('/terminal-theme', { colors, advice, variant })
+}
+
+// 导入色板文件（ASE / GPL / KPL / Procreate / DTCG tokens / CSS）
+export const importPalette = (file) => {
+  const form = new FormData()
+  form.append('file', file)
+  return apiClient.post('/import', form)
+}
+
+// 统计 CSS / SVG / HTML 中使用的颜色
+export const extractColors = (file, options = {}) => {
+  const form = new FormData()
+  form.append('file', file)
+  Object.entries(options).forEach(([key, value]) => form.append(key, value))
+  return apiClient.post('/extract-colors', form)
+}
+
+// 从图片中提取配色（本地量化）
+export const paletteFromImage = (file, options = {}) => {
+  const form = new FormData()
+  form.append('image', file)
+  Object.entries(options).forEach(([key, value]) => form.append(key, value))
+  return apiClient.post('/palette-from-image', form)
+}
+
+// 用配色重新着色图片，返回 PNG
+export const recolorImage = (file, colors, match = 'luminance') => {
+  const form = new FormData()
+  form.append('image', file)
+  form.append('colors', colors.join(','))
+  form.append('match', match)
+  return apiClient.post('/recolor', form, { responseType: 'blob' })
+}
+
+// 渲染配色预览界面（template: landing / dashboard / mobile，format: svg / png）
+export const renderPreview = (colors, options = {}) => {
+  return apiClient.post('/preview', { colors, ...options }, { responseType: 'blob' })
+}
+
+// 生成浅色/深色语义化界面主题（options: prompt / roles / use_ai）
+export const generateUITheme = (colors, options = {}) => {
+  return apiClient.post('/ui-theme', { colors, ...options })
+}
+
+// 生成每个颜色的色阶（kind: material / tailwind / all）
+export const generateScales = (colors, kind = 'all') => {
+  return apiClient.post('/scales', { colors, kind })
+}
+
+// 生成多段渐变（options: space / hue / midpoints / samples / angle / positions）
+export const generateGradient = (colors, options = {}) => {
+  return apiClient.post('/gradient', { colors, ...options })
+}
+
+// 渲染装饰背景图（style: mesh / blobs / lowpoly / stripes，format: svg / png，seed 相同结果相同）
+export const renderWallpaper = (colors, options = {}) => {
+  return apiClient.post('/wallpaper', { colors, ...options }, { responseType: 'blob' })
+}
+
+// 为已有配色逐色生成名称、角色、建议用量与理由
+export const explainPalette = (colors, prompt = '') => {
+  return apiClient.post('/explain-palette', { colors, prompt })
+}
+
+// 为配色打分：明度阶梯、色相和谐、可区分度、对比度、色盲可辨性与彩度平衡，总分 0–100
+export const scorePalette = (colors) => {
+  return apiClient.post('/score', { colors })
+}
+
+// 识别配色遵循的经典方案（互补、分裂互补、三角等）及偏差角度
+export const detectHarmony = (colors) => {
+  return apiClient.post('/harmony', { colors })
+}
+
+// 以最小的色相旋转使配色贴合方案，明度与彩度不变（scheme 为空时取最接近的方案）
+export const harmonizePalette = (colors, options = {}) => {
+  return apiClient.post('/harmonize', { colors, ...options })
+}
+
+// 查询单个颜色的数值表示与最接近的 CSS、英文及中国传统色名
+export const getColorInfo = (hex) => {
+  return apiClient.get(`/color/${encodeURIComponent(hex.replace(/^#/, ''))}`)
+}
+
+// 按ID获取已保存的配色
+export const getPalette = (id) => {
+  return apiClient.get(`/p/${id}`)
+}
+
+// 全格式打包下载地址
+export const bundleUrl = (id) => `${API_BASE_URL}/p/${id}/bundle.zip`
+
+// 已解析的模型结构化输出能力档案，当前模型尚未使用时 model 为 null
+export const getCapabilities = () => {
+  return apiClient.get('/capabilities')
+}
+
+// 月度 token 用量与费用，month 为 YYYY-MM（缺省为本月），需传入 ADMIN_TOKEN
+export const getUsage = (month, token) => {
+  return apiClient.get('/admin/usage', {
+    params: month ? { month } : {},
+    headers: token ? { Authorization: `Bearer ${token}` } : {}
+  })
+}
+
 // 健康检查
 export const healthCheck = () => {
   return apiClient.get('/health')